@@ -0,0 +1,124 @@
+package timefmt
+
+import "strings"
+
+// FormatInfo represents the properties of a format.
+type FormatInfo struct {
+	// Unit is the smallest unit of the directives, or zero if the format has
+	// no directives of time.
+	Unit Unit
+	// Fraction reports whether the format has the fraction of seconds (%f).
+	Fraction bool
+	// HasYear reports whether the format has the year of four digits or the
+	// unix time.
+	HasYear bool
+	// HasZone reports whether the format has the time zone offset or the unix
+	// time. The time zone name (%Z) is not counted since the abbreviations
+	// are ambiguous.
+	HasZone bool
+	// Complete reports whether the format has all the components of the time
+	// down to the smallest unit, like the month and the day for the hour.
+	Complete bool
+	// Unique reports whether the formatted string identifies an instant; the
+	// format is complete and has the time zone.
+	Unique bool
+	// Sortable reports whether the lexicographic order of the formatted
+	// strings equals the chronological order in a location, for the years
+	// from 0 to 9999. The directives are required to be of fixed widths, and
+	// ordered from the year to the smallest unit.
+	Sortable bool
+	// RoundTrip reports whether Parse(Format(t)) recovers the time t truncated
+	// to the smallest unit, in the same time zone if the format has %z, and
+	// otherwise in UTC.
+	RoundTrip bool
+}
+
+// sortableChains is the list of the sequences of the directives whose
+// lexicographic order equals the chronological order.
+var sortableChains = []string{"YmdHMSf", "YjHMSf", "GVuHMSf"}
+
+// Analyze returns the properties of the format.
+func Analyze(format string) FormatInfo {
+	var info FormatInfo
+	var directives, sequence []byte
+	parseable, fixed, zone := true, true, false
+	for _, token := range scanFormat(format) {
+		if token.Modifier != 0 {
+			// the fiscal directives are not supported by the parser
+			parseable = false
+			continue
+		}
+		switch token.Directive {
+		case 0, 't', 'n':
+			continue
+		case 'z', 'Z':
+			zone = true
+		case '%':
+		case 'f':
+			info.Fraction = true
+			fixed = fixed && !zone && (token.Padding == 0 || token.Padding == '0')
+			sequence = append(sequence, token.Directive)
+		default:
+			unit, width, err := directiveUnit(token)
+			if err != nil {
+				parseable = false
+				break
+			}
+			if info.Unit == 0 || unit < info.Unit {
+				info.Unit = unit
+			}
+			fixed = fixed && !zone && width > 0
+			sequence = append(sequence, token.Directive)
+		}
+		if token.Padding != 0 || token.Width != 0 || token.Upper || token.Swap {
+			parseable = false
+		}
+		directives = append(directives, token.Directive)
+	}
+	has := func(ds string) bool { return strings.ContainsAny(string(directives), ds) }
+	info.HasYear = has("YGs")
+	info.HasZone = has("zs")
+	info.Complete = has("s") || info.Unit != 0 &&
+		isCompleteDate(has, info.Unit) && isCompleteTime(has, info.Unit, info.Fraction)
+	info.Unique = info.Complete && info.HasZone
+	// the parser adds 12 hours to %H and %k on PM
+	info.RoundTrip = info.Complete && parseable && !(has("Hk") && has("pP"))
+	if fixed && len(sequence) > 0 {
+		for _, chain := range sortableChains {
+			info.Sortable = info.Sortable || strings.HasPrefix(chain, string(sequence))
+		}
+	}
+	return info
+}
+
+func isCompleteDate(has func(string) bool, unit Unit) bool {
+	switch {
+	case unit == UnitYear:
+		return has("Y")
+	case unit == UnitMonth:
+		return has("Y") && has("mBbh")
+	case unit == UnitWeek:
+		return has("G") && has("V") || has("Y") && has("UW")
+	default:
+		return has("Y") && (has("mBbh") && has("deo") || has("j")) ||
+			has("G") && has("V") && has("uwAa") ||
+			has("Y") && has("UW") && has("uwAa")
+	}
+}
+
+func isCompleteTime(has func(string) bool, unit Unit, fraction bool) bool {
+	if fraction && !has("S") {
+		return false
+	}
+	hour := has("Hk") || has("Il") && has("pP")
+	switch unit {
+	case UnitHour:
+		return hour
+	case UnitMinute:
+		return hour && has("M")
+	case UnitSecond:
+		return hour && has("M") && has("S")
+	default:
+		return !fraction
+	}
+}
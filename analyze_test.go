@@ -0,0 +1,218 @@
+package timefmt_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+)
+
+var analyzeTestCases = []struct {
+	format   string
+	expected timefmt.FormatInfo
+}{
+	{
+		format: "%Y-%m-%dT%H:%M:%S%z",
+		expected: timefmt.FormatInfo{
+			Unit: timefmt.UnitSecond, HasYear: true, HasZone: true,
+			Complete: true, Unique: true, Sortable: true, RoundTrip: true,
+		},
+	},
+	{
+		format: "%Y-%m-%d %H:%M:%S.%f",
+		expected: timefmt.FormatInfo{
+			Unit: timefmt.UnitSecond, Fraction: true, HasYear: true,
+			Complete: true, Sortable: true, RoundTrip: true,
+		},
+	},
+	{
+		format: "%F",
+		expected: timefmt.FormatInfo{
+			Unit: timefmt.UnitDay, HasYear: true,
+			Complete: true, Sortable: true, RoundTrip: true,
+		},
+	},
+	{
+		format: "dt=%Y%m/hr=%H",
+		expected: timefmt.FormatInfo{
+			Unit: timefmt.UnitHour, HasYear: true,
+		},
+	},
+	{
+		format: "%G-W%V-%u",
+		expected: timefmt.FormatInfo{
+			Unit: timefmt.UnitDay, HasYear: true,
+			Complete: true, Sortable: true, RoundTrip: true,
+		},
+	},
+	{
+		format: "%Y-%j",
+		expected: timefmt.FormatInfo{
+			Unit: timefmt.UnitDay, HasYear: true,
+			Complete: true, Sortable: true, RoundTrip: true,
+		},
+	},
+	{
+		format: "%d/%b/%Y:%T %z",
+		expected: timefmt.FormatInfo{
+			Unit: timefmt.UnitSecond, HasYear: true, HasZone: true,
+			Complete: true, Unique: true, RoundTrip: true,
+		},
+	},
+	{
+		format: "%a %b %e %H:%M:%S %Z %Y",
+		expected: timefmt.FormatInfo{
+			Unit: timefmt.UnitSecond, HasYear: true,
+			Complete: true, RoundTrip: true,
+		},
+	},
+	{
+		format: "%Y-%m-%d %I:%M %p",
+		expected: timefmt.FormatInfo{
+			Unit: timefmt.UnitMinute, HasYear: true,
+			Complete: true, RoundTrip: true,
+		},
+	},
+	{
+		format: "%Y-%m-%d %H:%M %p",
+		expected: timefmt.FormatInfo{
+			Unit: timefmt.UnitMinute, HasYear: true, Complete: true,
+		},
+	},
+	{
+		format: "%F %k:%M %P",
+		expected: timefmt.FormatInfo{
+			Unit: timefmt.UnitMinute, HasYear: true, Complete: true,
+		},
+	},
+	{
+		format: "%Y-%m-%d %I:%M",
+		expected: timefmt.FormatInfo{
+			Unit: timefmt.UnitMinute, HasYear: true,
+		},
+	},
+	{
+		format: "%Y-%-m-%-d",
+		expected: timefmt.FormatInfo{
+			Unit: timefmt.UnitDay, HasYear: true, Complete: true,
+		},
+	},
+	{
+		format: "%m/%d/%y",
+		expected: timefmt.FormatInfo{
+			Unit: timefmt.UnitDay,
+		},
+	},
+	{
+		format: "%s",
+		expected: timefmt.FormatInfo{
+			Unit: timefmt.UnitSecond, HasYear: true, HasZone: true,
+			Complete: true, Unique: true, RoundTrip: true,
+		},
+	},
+	{
+		format: "%H:%M",
+		expected: timefmt.FormatInfo{
+			Unit: timefmt.UnitMinute,
+		},
+	},
+	{
+		format:   "app.log",
+		expected: timefmt.FormatInfo{},
+	},
+}
+
+func TestAnalyze(t *testing.T) {
+	for _, tc := range analyzeTestCases {
+		t.Run(tc.format, func(t *testing.T) {
+			got := timefmt.Analyze(tc.format)
+			if got != tc.expected {
+				t.Errorf("expected: %+v, got: %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestAnalyzeRoundTrip(t *testing.T) {
+	for _, tc := range analyzeTestCases {
+		if tc.expected.Unit == 0 {
+			continue
+		}
+		t.Run(tc.format, func(t *testing.T) {
+			loc := time.UTC
+			if strings.Contains(tc.format, "%z") {
+				loc = time.FixedZone("", -(3*60+30)*60)
+			}
+			times := []time.Time{
+				time.Date(1901, time.February, 3, 4, 5, 6, 0, loc),
+				time.Date(2099, time.November, 12, 13, 14, 15, 0, loc),
+			}
+			for tm := time.Date(1999, time.December, 31, 7, 8, 9, 123456000, loc); tm.Year() < 2002; tm = tm.Add(37*time.Hour + 11*time.Minute) {
+				times = append(times, tm)
+			}
+			var failure string
+			for _, tm := range times {
+				s := timefmt.Format(tm, tc.format)
+				got, err := timefmt.ParseInLocation(s, tc.format, loc)
+				if expected := truncateUnit(tm, tc.expected.Unit, tc.expected.Fraction); err != nil || !got.Equal(expected) {
+					failure = fmt.Sprintf("expected %q to be recovered to %v but got: %v, %v", s, expected, got, err)
+					break
+				}
+			}
+			if tc.expected.RoundTrip && failure != "" {
+				t.Error(failure)
+			} else if !tc.expected.RoundTrip && failure == "" {
+				t.Errorf("expected %q not to round-trip", tc.format)
+			}
+		})
+	}
+}
+
+func truncateUnit(t time.Time, unit timefmt.Unit, fraction bool) time.Time {
+	year, month, day := t.Date()
+	hour, minute, second := t.Clock()
+	switch unit {
+	case timefmt.UnitYear:
+		month, day, hour, minute, second = time.January, 1, 0, 0, 0
+	case timefmt.UnitMonth:
+		day, hour, minute, second = 1, 0, 0, 0
+	case timefmt.UnitDay:
+		hour, minute, second = 0, 0, 0
+	case timefmt.UnitHour:
+		minute, second = 0, 0
+	case timefmt.UnitMinute:
+		second = 0
+	}
+	var nanosecond int
+	if fraction {
+		nanosecond = t.Nanosecond() / 1000 * 1000
+	}
+	return time.Date(year, month, day, hour, minute, second, nanosecond, t.Location())
+}
+
+func TestAnalyzeSortable(t *testing.T) {
+	for _, tc := range analyzeTestCases {
+		if !tc.expected.Sortable {
+			continue
+		}
+		t.Run(tc.format, func(t *testing.T) {
+			prev := timefmt.Format(time.Date(1999, time.December, 31, 7, 8, 9, 0, time.UTC), tc.format)
+			for tm := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC); tm.Year() < 2002; tm = tm.Add(37*time.Hour + 11*time.Minute) {
+				s := timefmt.Format(tm, tc.format)
+				if s < prev {
+					t.Fatalf("expected %q to be sorted after %q", s, prev)
+				}
+				prev = s
+			}
+		})
+	}
+}
+
+func ExampleAnalyze() {
+	info := timefmt.Analyze("%Y-%m-%d %H:%M")
+	fmt.Println(info.Unit, info.Unique, info.Sortable, info.RoundTrip)
+	// Output:
+	// minute false true true
+}
@@ -0,0 +1,339 @@
+package timefmt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateOrder is the preference of the order of ambiguous numeric dates.
+type DateOrder int
+
+// Preferences of the order of ambiguous numeric dates like 01/02/2020.
+const (
+	MonthFirst DateOrder = iota
+	DayFirst
+)
+
+// ParseAuto parses time string in an unknown format. It tokenizes the source,
+// guesses the format using the directives, and parses the source using the
+// format. It returns the guessed format so that the callers can use it for
+// parsing the strings of the same format using [Parse]. The order is used
+// for numeric dates which cannot be determined by their values. The time zone
+// name is resolved in the local time zone, and it returns an error if the
+// name is unknown to the time zone. For example, "Fri Jul 24 09:07:29 JST 2020"
+// fails unless the local time zone is Asia/Tokyo; use [ParseAutoInLocation]
+// with the location of the time zone name.
+func ParseAuto(source string, order DateOrder) (t time.Time, format string, err error) {
+	return parseAuto(source, order, time.UTC, time.Local)
+}
+
+// ParseAutoInLocation is like [ParseAuto] but parses with the default location,
+// which is also used to resolve the time zone name.
+func ParseAutoInLocation(source string, order DateOrder, loc *time.Location) (t time.Time, format string, err error) {
+	return parseAuto(source, order, loc, loc)
+}
+
+func parseAuto(source string, order DateOrder, loc, base *time.Location) (t time.Time, format string, err error) {
+	g := &autoGuesser{tokens: autoTokenize(source), order: order, hour: -1}
+	if err = g.guess(); err != nil {
+		return time.Time{}, "", fmt.Errorf("failed to guess format of %q: %w", source, err)
+	}
+	format = string(g.format)
+	if t, err = parse(source, format, loc, base, nil, nil); err != nil {
+		return time.Time{}, "", err
+	}
+	if g.zoneName != "" && !isKnownZone(g.zoneName, base) {
+		return time.Time{}, "", &ParseError{source, format,
+			fmt.Errorf("unknown time zone name %q", g.zoneName)}
+	}
+	return t, format, nil
+}
+
+// isKnownZone reports whether the time zone name is resolved in the location,
+// since time.ParseInLocation treats an unknown name as zero offset.
+func isKnownZone(name string, loc *time.Location) bool {
+	t, err := time.ParseInLocation("MST", name, loc)
+	return err == nil && (t.Location() == loc || name == "UTC" || strings.HasPrefix(name, "GMT"))
+}
+
+type autoToken struct {
+	kind byte // 'd' for digits, 'a' for letters, 'p' for others
+	text string
+}
+
+func autoTokenize(source string) []autoToken {
+	var tokens []autoToken
+	for i := 0; i < len(source); {
+		kind, j := autoKind(source[i]), i+1
+		if kind != 'p' {
+			for j < len(source) && autoKind(source[j]) == kind {
+				j++
+			}
+		}
+		tokens = append(tokens, autoToken{kind, source[i:j]})
+		i = j
+	}
+	return tokens
+}
+
+func autoKind(b byte) byte {
+	switch {
+	case '0' <= b && b <= '9':
+		return 'd'
+	case 'a' <= b|0x20 && b|0x20 <= 'z':
+		return 'a'
+	default:
+		return 'p'
+	}
+}
+
+type autoGuesser struct {
+	tokens                        []autoToken
+	order                         DateOrder
+	format                        []byte
+	hour                          int
+	hasYear, hasMonth, hasDay     bool
+	hasTime, hasZone, hasMeridiem bool
+	zoneName                      string
+}
+
+func (g *autoGuesser) guess() error {
+	if len(g.tokens) == 0 {
+		return errors.New("empty string")
+	}
+	for i := 0; i < len(g.tokens); i++ {
+		var err error
+		switch g.tokens[i].kind {
+		case 'd':
+			i, err = g.digits(i)
+		case 'a':
+			err = g.word(i)
+		default:
+			i = g.punct(i)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if !g.hasYear && !g.hasMonth && !g.hasDay && !g.hasTime {
+		return errors.New("no date or time found")
+	}
+	return nil
+}
+
+func (g *autoGuesser) peek(i int, kind byte, texts ...string) bool {
+	if i >= len(g.tokens) || g.tokens[i].kind != kind {
+		return false
+	}
+	if len(texts) == 0 {
+		return true
+	}
+	for _, text := range texts {
+		if strings.EqualFold(g.tokens[i].text, text) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *autoGuesser) emit(format string) {
+	g.format = append(g.format, format...)
+}
+
+func (g *autoGuesser) digits(i int) (int, error) {
+	text := g.tokens[i].text
+	switch {
+	case g.peek(i+1, 'p', ":") && g.peek(i+2, 'd') && len(text) <= 2 && !g.hasTime:
+		return g.clock(i), nil
+	case len(text) <= 2 && !g.hasTime &&
+		(g.peek(i+1, 'a', "am", "pm") || g.peek(i+1, 'p', " ") && g.peek(i+2, 'a', "am", "pm")):
+		g.hour, g.hasTime = len(g.format), true
+		g.emit("%I")
+		return i, nil
+	case g.peek(i+1, 'a', "st", "nd", "rd", "th") && len(text) <= 2 && !g.hasDay:
+		g.hasDay = true
+		g.emit("%o")
+		return i + 1, nil
+	case g.peek(i+1, 'p', "/", "-", ".") && g.peek(i+2, 'd') && !g.hasMonth:
+		return g.date(i)
+	case len(g.format) > 0 && g.format[len(g.format)-1] == 'T' && !g.hasTime:
+		g.hasTime = true
+		switch len(text) {
+		case 2:
+			g.emit("%H")
+		case 4:
+			g.emit("%H%M")
+		case 6:
+			g.emit("%H%M%S")
+		default:
+			return i, fmt.Errorf("unknown time %q", text)
+		}
+		if g.peek(i+1, 'p', ".", ",") && g.peek(i+2, 'd') && len(text) == 6 {
+			g.emit(g.tokens[i+1].text + "%f")
+			i += 2
+		}
+		return i, nil
+	case len(text) == 8 && !g.hasYear && !g.hasMonth:
+		g.hasYear, g.hasMonth, g.hasDay = true, true, true
+		g.emit("%Y%m%d")
+	case len(text) == 12 && !g.hasYear && !g.hasTime:
+		g.hasYear, g.hasMonth, g.hasDay, g.hasTime = true, true, true, true
+		g.emit("%Y%m%d%H%M")
+	case len(text) == 14 && !g.hasYear && !g.hasTime:
+		g.hasYear, g.hasMonth, g.hasDay, g.hasTime = true, true, true, true
+		g.emit("%Y%m%d%H%M%S")
+	case len(text) == 10 && len(g.tokens) == 1:
+		g.hasYear, g.hasMonth, g.hasDay, g.hasTime, g.hasZone = true, true, true, true, true
+		g.emit("%s")
+	case len(text) == 4 && !g.hasYear:
+		g.hasYear = true
+		g.emit("%Y")
+	case len(text) <= 2 && !g.hasDay:
+		g.hasDay = true
+		g.emit("%d")
+	case len(text) <= 2 && !g.hasYear:
+		g.hasYear = true
+		g.emit("%y")
+	default:
+		return i, fmt.Errorf("unknown number %q", text)
+	}
+	return i, nil
+}
+
+// clock handles time like 09:07, 09:07:29 and 09:07:29.123.
+func (g *autoGuesser) clock(i int) int {
+	g.hour, g.hasTime = len(g.format), true
+	g.emit("%H:%M")
+	i += 2
+	if g.peek(i+1, 'p', ":") && g.peek(i+2, 'd') {
+		g.emit(":%S")
+		i += 2
+		if g.peek(i+1, 'p', ".", ",") && g.peek(i+2, 'd') && len(g.tokens[i+2].text) <= 9 {
+			g.emit(g.tokens[i+1].text + "%f")
+			i += 2
+		}
+	}
+	return i
+}
+
+// date handles numeric dates like 2020-07-24, 7/24/2020 and 24.07.20.
+func (g *autoGuesser) date(i int) (int, error) {
+	sep := g.tokens[i+1].text
+	texts := []string{g.tokens[i].text, g.tokens[i+2].text}
+	if g.peek(i+3, 'p', sep) && g.peek(i+4, 'd') {
+		texts = append(texts, g.tokens[i+4].text)
+	}
+	var directives []string
+	switch {
+	case len(texts[0]) == 4:
+		directives = []string{"%Y", "%m", "%d"}
+	case len(texts) == 2 && len(texts[1]) == 4:
+		directives = []string{"%m", "%Y"}
+	case len(texts[0]) > 2 || len(texts[1]) > 2:
+		return i, fmt.Errorf("unknown date %q", strings.Join(texts, sep))
+	default:
+		first, second := atoi(texts[0]), atoi(texts[1])
+		if first > 12 || second <= 12 && g.order == DayFirst {
+			directives = []string{"%d", "%m"}
+		} else {
+			directives = []string{"%m", "%d"}
+		}
+		if len(texts) == 3 {
+			switch len(texts[2]) {
+			case 4:
+				directives = append(directives, "%Y")
+			case 2:
+				directives = append(directives, "%y")
+			default:
+				return i, fmt.Errorf("unknown year %q", texts[2])
+			}
+		}
+	}
+	for j, directive := range directives[:len(texts)] {
+		if j > 0 {
+			g.emit(sep)
+		}
+		g.emit(directive)
+		switch directive {
+		case "%Y", "%y":
+			g.hasYear = true
+		case "%m":
+			g.hasMonth = true
+		case "%d":
+			g.hasDay = true
+		}
+	}
+	return i + len(texts)*2 - 2, nil
+}
+
+func atoi(s string) (n int) {
+	for _, b := range []byte(s) {
+		n = n*10 + int(b-'0')
+	}
+	return
+}
+
+func (g *autoGuesser) word(i int) error {
+	text := g.tokens[i].text
+	switch {
+	case g.hasTime && !g.hasMeridiem && g.peek(i, 'a', "am", "pm"):
+		if g.hour >= 0 {
+			g.format[g.hour+1] = 'I'
+		}
+		g.hasMeridiem = true
+		g.emit("%p")
+	case !g.hasMonth && g.matchName(text, longMonthNames):
+		g.hasMonth = true
+		g.emit("%B")
+	case !g.hasMonth && g.matchName(text, shortMonthNames):
+		g.hasMonth = true
+		g.emit("%b")
+	case g.matchName(text, longWeekNames):
+		g.emit("%A")
+	case g.matchName(text, shortWeekNames):
+		g.emit("%a")
+	case (text == "T" || text == "t") && g.hasDay && !g.hasTime && g.peek(i+1, 'd'):
+		g.emit(text)
+	case (text == "Z" || text == "z") && g.hasTime && !g.hasZone:
+		g.hasZone = true
+		g.emit("%z")
+	case len(text) >= 3 && len(text) <= 5 && strings.ToUpper(text) == text && !g.hasZone:
+		g.hasZone, g.zoneName = true, text
+		g.emit("%Z")
+	case g.peek(i, 'a', "at", "on", "of", "the"):
+		g.emit(text)
+	default:
+		return fmt.Errorf("unknown word %q", text)
+	}
+	return nil
+}
+
+func (g *autoGuesser) matchName(text string, names []string) bool {
+	for _, name := range names {
+		if strings.EqualFold(text, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *autoGuesser) punct(i int) int {
+	text := g.tokens[i].text
+	if (text == "+" || text == "-") && g.hasTime && !g.hasZone && g.peek(i+1, 'd') {
+		if n := len(g.tokens[i+1].text); n == 2 || n == 4 {
+			g.hasZone = true
+			g.emit("%z")
+			if i++; n == 2 && g.peek(i+1, 'p', ":") && g.peek(i+2, 'd') {
+				i += 2
+			}
+			return i
+		}
+	}
+	if text == "%" {
+		text = "%%"
+	}
+	g.emit(text)
+	return i
+}
@@ -0,0 +1,208 @@
+package timefmt_test
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+)
+
+var parseAutoTestCases = []struct {
+	source   string
+	order    timefmt.DateOrder
+	format   string
+	t        time.Time
+	parseErr error
+}{
+	{
+		source: "7/24/2020",
+		format: "%m/%d/%Y",
+		t:      time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "24/7/2020",
+		format: "%d/%m/%Y",
+		t:      time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "01/02/2020",
+		format: "%m/%d/%Y",
+		t:      time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "01/02/2020",
+		order:  timefmt.DayFirst,
+		format: "%d/%m/%Y",
+		t:      time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "24.07.20",
+		order:  timefmt.DayFirst,
+		format: "%d.%m.%y",
+		t:      time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "2020.07.24",
+		format: "%Y.%m.%d",
+		t:      time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "2020-07",
+		format: "%Y-%m",
+		t:      time.Date(2020, time.July, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "24 July 2020 9am",
+		format: "%d %B %Y %I%p",
+		t:      time.Date(2020, time.July, 24, 9, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "July 24th, 2020 at 9:30 PM",
+		format: "%B %o, %Y at %I:%M %p",
+		t:      time.Date(2020, time.July, 24, 21, 30, 0, 0, time.UTC),
+	},
+	{
+		source: "Fri Jul 24 09:07:29 UTC 2020",
+		format: "%a %b %d %H:%M:%S %Z %Y",
+		t:      time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC),
+	},
+	{
+		source: "Fri Jul  4 09:07:29 2020",
+		format: "%a %b  %d %H:%M:%S %Y",
+		t:      time.Date(2020, time.July, 4, 9, 7, 29, 0, time.UTC),
+	},
+	{
+		source: "Friday, 24 Jul 2020 09:07:29 +0900",
+		format: "%A, %d %b %Y %H:%M:%S %z",
+		t:      time.Date(2020, time.July, 24, 0, 7, 29, 0, time.UTC),
+	},
+	{
+		source: "2020-07-24T09:07:29.123Z",
+		format: "%Y-%m-%dT%H:%M:%S.%f%z",
+		t:      time.Date(2020, time.July, 24, 9, 7, 29, 123000000, time.UTC),
+	},
+	{
+		source: "2020-07-24 09:07:29-07:00",
+		format: "%Y-%m-%d %H:%M:%S%z",
+		t:      time.Date(2020, time.July, 24, 16, 7, 29, 0, time.UTC),
+	},
+	{
+		source: "20200724T090729",
+		format: "%Y%m%dT%H%M%S",
+		t:      time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC),
+	},
+	{
+		source: "20200724090729",
+		format: "%Y%m%d%H%M%S",
+		t:      time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC),
+	},
+	{
+		source: "1595581649",
+		format: "%s",
+		t:      time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC),
+	},
+	{
+		source: "2020-07-24 09:07:29.123456789",
+		format: "%Y-%m-%d %H:%M:%S.%f",
+		t:      time.Date(2020, time.July, 24, 9, 7, 29, 123456789, time.UTC),
+	},
+	{
+		source:   "Fri Jul 24 09:07:29 XYZT 2020",
+		parseErr: errors.New(`unknown time zone name "XYZT"`),
+	},
+	{
+		source:   "tomorrow",
+		parseErr: errors.New(`unknown word "tomorrow"`),
+	},
+	{
+		source:   "123",
+		parseErr: errors.New(`unknown number "123"`),
+	},
+	{
+		source:   "",
+		parseErr: errors.New("empty string"),
+	},
+	{
+		source:   "13/13/2020",
+		parseErr: errors.New(`cannot parse "%m"`),
+	},
+}
+
+func TestParseAuto(t *testing.T) {
+	for _, tc := range parseAutoTestCases {
+		t.Run(tc.source, func(t *testing.T) {
+			got, format, err := timefmt.ParseAuto(tc.source, tc.order)
+			if tc.parseErr == nil {
+				if err != nil {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				if format != tc.format {
+					t.Errorf("expected format: %q, got: %q", tc.format, format)
+				}
+				if !got.Equal(tc.t) {
+					t.Errorf("expected: %v, got: %v", tc.t, got)
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("expected error %v but got: %v", tc.parseErr, err)
+				}
+				if !strings.Contains(err.Error(), tc.parseErr.Error()) {
+					t.Errorf("expected: %v, got: %v", tc.parseErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestParseAutoInLocation(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skip(err)
+	}
+	got, format, err := timefmt.ParseAutoInLocation("Fri Jul 24 09:07:29 JST 2020", timefmt.MonthFirst, loc)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if expected := "%a %b %d %H:%M:%S %Z %Y"; format != expected {
+		t.Errorf("expected format: %q, got: %q", expected, format)
+	}
+	if expected := time.Date(2020, time.July, 24, 9, 7, 29, 0, loc); !got.Equal(expected) {
+		t.Errorf("expected: %v, got: %v", expected, got)
+	}
+	if _, offset := got.Zone(); offset != 9*60*60 {
+		t.Errorf("expected offset: %d, got: %d", 9*60*60, offset)
+	}
+	got, _, err = timefmt.ParseAutoInLocation("2020-07-24 09:07:29", timefmt.MonthFirst, loc)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if got.Location() != loc {
+		t.Errorf("expected location: %v, got: %v", loc, got.Location())
+	}
+	for _, loc := range []*time.Location{loc, time.UTC} {
+		source := "Fri Jul 24 09:07:29 EST 2020"
+		if loc == time.UTC {
+			source = "Fri Jul 24 09:07:29 JST 2020"
+		}
+		_, _, err := timefmt.ParseAutoInLocation(source, timefmt.MonthFirst, loc)
+		if expected := "unknown time zone name"; err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error %q but got: %v", expected, err)
+		}
+	}
+}
+
+func ExampleParseAuto() {
+	t, format, err := timefmt.ParseAuto("24 July 2020 9:07am", timefmt.DayFirst)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(t)
+	fmt.Println(format)
+	// Output:
+	// 2020-07-24 09:07:00 +0000 UTC
+	// %d %B %Y %I:%M%p
+}
@@ -0,0 +1,211 @@
+// Command timefmt formats and parses date time strings using the directives
+// of strftime and strptime.
+//
+//	timefmt [-d string | -r file | -epoch seconds] [-u | -z zone] [+format]
+//	timefmt -i format [-o format] [-u | -z zone] [string ...]
+//
+// It prints the current time, or the time specified by the flags, in the
+// output format. With the input format, it parses the arguments, or the lines
+// of the standard input, and prints each time in the output format. The time
+// zone defaults to the TZ environment variable.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+	"github.com/itchyny/timefmt-go/relative"
+)
+
+const name = "timefmt"
+
+const defaultFormat = "%a %b %e %H:%M:%S %Z %Y"
+
+var now = time.Now
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+type cli struct {
+	date, file, epoch, input, output, zone string
+	utc                                    bool
+	loc                                    *time.Location
+	stdout, stderr                         io.Writer
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	c := &cli{stdout: stdout, stderr: stderr}
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, `Usage:
+  %[1]s [-d string | -r file | -epoch seconds] [-u | -z zone] [+format]
+  %[1]s -i format [-o format] [-u | -z zone] [string ...]
+
+Options:
+`, name)
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&c.date, "d", "", "display the time described by the `string`")
+	fs.StringVar(&c.file, "r", "", "display the last modification time of the `file`")
+	fs.StringVar(&c.epoch, "epoch", "", "display the time of the Unix epoch `seconds`")
+	fs.StringVar(&c.input, "i", "", "parse the strings using the input `format`")
+	fs.StringVar(&c.output, "o", defaultFormat, "output `format`")
+	fs.StringVar(&c.zone, "z", "", "time `zone` name (defaults to $TZ)")
+	fs.BoolVar(&c.utc, "u", false, "use UTC")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if err := c.setLocation(); err != nil {
+		return c.fail(err)
+	}
+	args = fs.Args()
+	if c.input != "" && c.date == "" {
+		return c.convert(args, stdin)
+	}
+	if len(args) > 0 && strings.HasPrefix(args[0], "+") {
+		c.output, args = args[0][1:], args[1:]
+	}
+	if len(args) > 0 {
+		fmt.Fprintf(stderr, "%s: unexpected argument %q\n", name, args[0])
+		return 2
+	}
+	t, err := c.time()
+	if err != nil {
+		return c.fail(err)
+	}
+	fmt.Fprintln(stdout, timefmt.Format(t.In(c.loc), c.output))
+	return 0
+}
+
+func (c *cli) setLocation() (err error) {
+	switch {
+	case c.utc && c.zone != "":
+		err = errors.New(`cannot specify both "-u" and "-z"`)
+	case c.utc:
+		c.loc = time.UTC
+	case c.zone != "":
+		c.loc, err = time.LoadLocation(c.zone)
+	default:
+		c.loc = time.Local
+	}
+	return
+}
+
+// time returns the time specified by -d, -r or -epoch, or the current time.
+func (c *cli) time() (time.Time, error) {
+	var n int
+	for _, s := range []string{c.date, c.file, c.epoch} {
+		if s != "" {
+			n++
+		}
+	}
+	switch {
+	case n > 1:
+		return time.Time{}, errors.New(`cannot specify more than one of "-d", "-r" and "-epoch"`)
+	case c.date != "":
+		if c.input != "" {
+			return timefmt.ParseInLocation(c.date, c.input, c.loc)
+		}
+		return c.parseDate(c.date)
+	case c.file != "":
+		fi, err := os.Stat(c.file)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return fi.ModTime(), nil
+	case c.epoch != "":
+		return parseEpoch(c.epoch)
+	default:
+		return now(), nil
+	}
+}
+
+// parseEpoch parses the seconds since the epoch with an optional fraction. The
+// fraction of a negative value is subtracted like @-1.5 of GNU date.
+func parseEpoch(s string) (time.Time, error) {
+	seconds, fraction, ok := strings.Cut(s, ".")
+	t, err := timefmt.Parse(seconds, "%s")
+	if err != nil || !ok {
+		return t, err
+	}
+	d, err := timefmt.ParseDuration(fraction, "%N")
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid fraction of epoch %q", s)
+	}
+	if strings.HasPrefix(seconds, "-") {
+		d = -d
+	}
+	return t.Add(d), nil
+}
+
+// parseDate parses the relative expression or the string of an unknown format.
+func (c *cli) parseDate(s string) (time.Time, error) {
+	p := &relative.Parser{Now: func() time.Time { return now().In(c.loc) }}
+	t, err := p.Parse(s)
+	if err == nil {
+		return t, nil
+	}
+	if t, format, err := timefmt.ParseAuto(s, timefmt.MonthFirst); err == nil {
+		if strings.Contains(format, "%z") || strings.Contains(format, "%Z") ||
+			strings.Contains(format, "%s") {
+			return t, nil
+		}
+		return timefmt.ParseInLocation(s, format, c.loc)
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
+
+// convert parses the arguments, or the lines of the standard input, using the
+// input format, and prints them in the output format.
+func (c *cli) convert(args []string, stdin io.Reader) int {
+	var code int
+	if len(args) > 0 {
+		for _, arg := range args {
+			if !c.convertLine(arg, 0) {
+				code = 1
+			}
+		}
+		return code
+	}
+	s := bufio.NewScanner(stdin)
+	for line := 1; s.Scan(); line++ {
+		if !c.convertLine(s.Text(), line) {
+			code = 1
+		}
+	}
+	if err := s.Err(); err != nil {
+		return c.fail(err)
+	}
+	return code
+}
+
+func (c *cli) convertLine(source string, line int) bool {
+	t, err := timefmt.ParseInLocation(source, c.input, c.loc)
+	if err != nil {
+		if line > 0 {
+			fmt.Fprintf(c.stderr, "%s: line %d: %s\n", name, line, err)
+		} else {
+			fmt.Fprintf(c.stderr, "%s: %s\n", name, err)
+		}
+		return false
+	}
+	fmt.Fprintln(c.stdout, timefmt.Format(t.In(c.loc), c.output))
+	return true
+}
+
+func (c *cli) fail(err error) int {
+	fmt.Fprintf(c.stderr, "%s: %s\n", name, err)
+	return 1
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var runTestCases = []struct {
+	name     string
+	args     []string
+	input    string
+	expected string
+	errors   string
+	code     int
+}{
+	{
+		name:     "now",
+		args:     []string{"-u"},
+		expected: "Fri Jul 24 09:07:29 UTC 2020\n",
+	},
+	{
+		name:     "now with format",
+		args:     []string{"-z", "Asia/Tokyo", "+%F %T %z"},
+		expected: "2020-07-24 18:07:29 +0900\n",
+	},
+	{
+		name:     "output format",
+		args:     []string{"-u", "-o", "%s"},
+		expected: "1595581649\n",
+	},
+	{
+		name:     "date",
+		args:     []string{"-u", "-d", "2020-01-02 03:04:05", "+%FT%T"},
+		expected: "2020-01-02T03:04:05\n",
+	},
+	{
+		name:     "relative date",
+		args:     []string{"-u", "-d", "tomorrow 9:00", "+%FT%T"},
+		expected: "2020-07-25T09:00:00\n",
+	},
+	{
+		name:     "date in unknown format",
+		args:     []string{"-z", "Asia/Tokyo", "-d", "Jul 24, 2020 9:07pm", "+%FT%T%:z"},
+		expected: "2020-07-24T21:07:00+09:00\n",
+	},
+	{
+		name:     "date with zone",
+		args:     []string{"-u", "-d", "24 Jul 2020 09:07:29 +0900", "+%FT%T%:z"},
+		expected: "2020-07-24T00:07:29+00:00\n",
+	},
+	{
+		name:     "date with input format",
+		args:     []string{"-u", "-i", "%d/%b/%Y", "-d", "24/Jul/2020", "+%F"},
+		expected: "2020-07-24\n",
+	},
+	{
+		name:     "epoch",
+		args:     []string{"-u", "-epoch", "1595581649.5", "+%F %T.%f"},
+		expected: "2020-07-24 09:07:29.500000\n",
+	},
+	{
+		name:     "negative epoch with fraction",
+		args:     []string{"-u", "-epoch", "-1.5", "+%F %T.%f"},
+		expected: "1969-12-31 23:59:58.500000\n",
+	},
+	{
+		name:     "negative epoch between -1 and 0",
+		args:     []string{"-u", "-epoch", "-0.25", "+%F %T.%f"},
+		expected: "1969-12-31 23:59:59.750000\n",
+	},
+	{
+		name:     "convert arguments",
+		args:     []string{"-u", "-i", "%d/%b/%Y:%T %z", "-o", "%FT%T%:z", "24/Jul/2020:09:07:29 +0900", "01/Aug/2020:00:00:00 +0000"},
+		expected: "2020-07-24T00:07:29+00:00\n2020-08-01T00:00:00+00:00\n",
+	},
+	{
+		name:     "convert stdin",
+		args:     []string{"-z", "Asia/Tokyo", "-i", "%d/%b/%Y", "-o", "%F %z"},
+		input:    "24/Jul/2020\n31/Jul/2020\n",
+		expected: "2020-07-24 +0900\n2020-07-31 +0900\n",
+	},
+	{
+		name:     "convert stdin error",
+		args:     []string{"-u", "-i", "%d/%b/%Y", "-o", "%F"},
+		input:    "24/Jul/2020\n2020-07-25\n26/Jul/2020\n",
+		expected: "2020-07-24\n2020-07-26\n",
+		errors:   `timefmt: line 2: failed to parse "2020-07-25" with "%d/%b/%Y": expected '/'` + "\n",
+		code:     1,
+	},
+	{
+		name:   "convert argument error",
+		args:   []string{"-u", "-i", "%Y", "x"},
+		errors: `timefmt: failed to parse "x" with "%Y": cannot parse "%Y"` + "\n",
+		code:   1,
+	},
+	{
+		name:   "invalid date",
+		args:   []string{"-d", "someday"},
+		errors: `timefmt: invalid date "someday"` + "\n",
+		code:   1,
+	},
+	{
+		name:   "invalid zone",
+		args:   []string{"-z", "Unknown/Zone"},
+		errors: "timefmt: unknown time zone Unknown/Zone\n",
+		code:   1,
+	},
+	{
+		name:   "both utc and zone",
+		args:   []string{"-u", "-z", "Asia/Tokyo"},
+		errors: `timefmt: cannot specify both "-u" and "-z"` + "\n",
+		code:   1,
+	},
+	{
+		name:   "multiple times",
+		args:   []string{"-d", "today", "-epoch", "0"},
+		errors: `timefmt: cannot specify more than one of "-d", "-r" and "-epoch"` + "\n",
+		code:   1,
+	},
+	{
+		name:   "unexpected argument",
+		args:   []string{"%F"},
+		errors: `timefmt: unexpected argument "%F"` + "\n",
+		code:   2,
+	},
+	{
+		name: "unknown flag",
+		args: []string{"-x"},
+		code: 2,
+	},
+}
+
+func TestRun(t *testing.T) {
+	now = func() time.Time { return time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC) }
+	defer func() { now = time.Now }()
+	for _, tc := range runTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout, stderr strings.Builder
+			code := run(tc.args, strings.NewReader(tc.input), &stdout, &stderr)
+			if code != tc.code {
+				t.Errorf("expected exit code %d but got: %d (%s)", tc.code, code, stderr.String())
+			}
+			if got := stdout.String(); got != tc.expected {
+				t.Errorf("expected: %q, got: %q", tc.expected, got)
+			}
+			if got := stderr.String(); tc.errors != "" && got != tc.errors {
+				t.Errorf("expected errors: %q, got: %q", tc.errors, got)
+			}
+		})
+	}
+}
+
+func TestRunFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mtime := time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC)
+	if err := os.Chtimes(file, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr strings.Builder
+	if code := run([]string{"-u", "-r", file, "+%F %T"}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected exit code 0 but got: %d (%s)", code, stderr.String())
+	}
+	if got, expected := stdout.String(), "2020-07-24 09:07:29\n"; got != expected {
+		t.Errorf("expected: %q, got: %q", expected, got)
+	}
+}
@@ -0,0 +1,131 @@
+package timefmt
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PlaceholderStyle is the style of the placeholder of a format.
+type PlaceholderStyle int
+
+// Styles of the placeholders.
+const (
+	// PlaceholderLetters represents the values with letters, like YYYY-MM-DD hh:mm.
+	PlaceholderLetters PlaceholderStyle = iota
+	// PlaceholderMask represents the digits with underscores, like ____-__-__ __:__,
+	// for the input masks. The names are represented with letters.
+	PlaceholderMask
+)
+
+// Placeholder returns the placeholder of the format for the input hints, like
+// YYYY-MM-DD hh:mm for %Y-%m-%d %H:%M.
+func Placeholder(format string, style PlaceholderStyle) string {
+	var sb strings.Builder
+	for _, token := range scanFormat(format) {
+		info, ok := lookupDirective(token.Modifier, token.Directive)
+		if !ok {
+			sb.WriteString(token.Literal)
+			continue
+		}
+		placeholder := info.Placeholder
+		if token.Directive == 'z' {
+			placeholder = [...]string{"+hhmm", "+hh:mm", "+hh:mm:ss", "+hh"}[token.Colons]
+		}
+		switch {
+		case !info.Numeric:
+			if token.Width > len(placeholder) {
+				placeholder = strings.Repeat(" ", token.Width-len(placeholder)) + placeholder
+			}
+		case strings.Count(placeholder, placeholder[:1]) == len(placeholder):
+			switch {
+			case token.Padding == '-' && token.Width == 0:
+				placeholder = placeholder[:1]
+			case token.Width > len(placeholder):
+				placeholder = strings.Repeat(placeholder[:1], token.Width)
+			}
+		}
+		if token.Upper {
+			placeholder = strings.ToUpper(placeholder)
+		}
+		if style == PlaceholderMask && info.Numeric {
+			placeholder = strings.Map(func(r rune) rune {
+				if 'A' <= r && r <= 'Z' || 'a' <= r && r <= 'z' {
+					return '_'
+				}
+				return r
+			}, placeholder)
+		}
+		sb.WriteString(placeholder)
+	}
+	return sb.String()
+}
+
+// DescribeDirective returns the English description of the directive token
+// for [Describe]. Use [Locale] to localize the descriptions.
+func DescribeDirective(token Token) string {
+	info, ok := lookupDirective(token.Modifier, token.Directive)
+	if !ok {
+		return strconv.Quote(token.Literal)
+	}
+	description, _, _ := strings.Cut(info.Description, ";")
+	switch token.Colons {
+	case 1:
+		description += " with colon"
+	case 2:
+		description += " with colons and seconds"
+	case 3:
+		description += " in the shortest form"
+	}
+	switch token.paddingByte() {
+	case ^paddingMask:
+		description += " without padding"
+	case ' ' | ^paddingMask:
+		description += " padded with spaces"
+	case '0' | ^paddingMask:
+		description += " padded with zeros"
+	}
+	if token.Width > 0 {
+		description += " in " + strconv.Itoa(token.Width) + " columns"
+	}
+	if token.Upper {
+		description += " in upper case"
+	} else if token.Swap {
+		description += " in swapped case"
+	}
+	return description
+}
+
+// Describe returns the description of the format in words, like `year, "-",
+// month, "-", day of month` for %Y-%m-%d. The directives are described
+// by [DescribeDirective], and the literal strings are quoted except for the
+// spaces.
+func Describe(format string) string {
+	return describe(format, nil)
+}
+
+func describe(format string, lc *Locale) string {
+	var descriptions []string
+	for _, token := range scanFormat(format) {
+		if token.Directive != 0 {
+			descriptions = append(descriptions, lc.describeDirective(token))
+		} else if strings.TrimSpace(token.Literal) != "" {
+			descriptions = append(descriptions, strconv.Quote(token.Literal))
+		}
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// exampleTime is the time used by [Example] for the zero time, whose
+// components are distinguishable from each other.
+var exampleTime = time.Date(2020, time.July, 24, 21, 7, 29, 123456000, time.UTC)
+
+// Example returns an example of the string formatted by the format. If the
+// time is zero, a time whose components are distinguishable from each other
+// is used instead.
+func Example(format string, t time.Time) string {
+	if t.IsZero() {
+		t = exampleTime
+	}
+	return Format(t, format)
+}
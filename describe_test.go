@@ -0,0 +1,161 @@
+package timefmt_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+)
+
+var placeholderTestCases = []struct {
+	format  string
+	letters string
+	mask    string
+}{
+	{
+		format:  "%Y-%m-%d %H:%M",
+		letters: "YYYY-MM-DD hh:mm",
+		mask:    "____-__-__ __:__",
+	},
+	{
+		format:  "%F %T.%f %z",
+		letters: "YYYY-MM-DD hh:mm:ss.ffffff +hhmm",
+		mask:    "____-__-__ __:__:__.______ +____",
+	},
+	{
+		format:  "%a, %d %b %Y %:z",
+		letters: "Wkd, DD Mon YYYY +hh:mm",
+		mask:    "Wkd, __ Mon ____ +__:__",
+	},
+	{
+		format:  "%-m/%-d/%y %I:%M %p",
+		letters: "M/D/YY hh:mm AM",
+		mask:    "_/_/__ __:__ AM",
+	},
+	{
+		format:  "%^B %5Y %o %G-W%V-%u %%",
+		letters: "MONTH YYYYY DDth GGGG-WWW-u %",
+		mask:    "MONTH _____ DDth ____-W__-_ %",
+	},
+	{
+		format:  "%-3d FY%EY-Q%Eq",
+		letters: "DDD FYYYYY-QQ",
+		mask:    "___ FY____-Q_",
+	},
+	{
+		format:  "%3% %10B",
+		letters: "  %      Month",
+		mask:    "  %      Month",
+	},
+	{
+		format:  "%E %",
+		letters: "%E %",
+		mask:    "%E %",
+	},
+}
+
+func TestPlaceholder(t *testing.T) {
+	for _, tc := range placeholderTestCases {
+		t.Run(tc.format, func(t *testing.T) {
+			if got := timefmt.Placeholder(tc.format, timefmt.PlaceholderLetters); got != tc.letters {
+				t.Errorf("expected: %q, got: %q", tc.letters, got)
+			}
+			if got := timefmt.Placeholder(tc.format, timefmt.PlaceholderMask); got != tc.mask {
+				t.Errorf("expected: %q, got: %q", tc.mask, got)
+			}
+		})
+	}
+}
+
+var describeTestCases = []struct {
+	format   string
+	expected string
+}{
+	{
+		format:   "%a, %d %b %Y",
+		expected: `abbreviated weekday name, ", ", day of month, abbreviated month name, year`,
+	},
+	{
+		format:   "%F",
+		expected: `year, "-", month, "-", day of month`,
+	},
+	{
+		format:   "%-d %_3j %^a %#p %:z %::z %:::z",
+		expected: `day of month without padding, day of year padded with spaces in 3 columns, abbreviated weekday name in upper case, AM or PM in swapped case, time zone offset with colon, time zone offset with colons and seconds, time zone offset in the shortest form`,
+	},
+	{
+		format:   "%-3d %EY",
+		expected: `day of month padded with spaces in 3 columns, fiscal year`,
+	},
+	{
+		format:   "[%T] %E",
+		expected: `"[", hour, ":", minute, ":", second, "] ", "%E"`,
+	},
+}
+
+func TestDescribe(t *testing.T) {
+	for _, tc := range describeTestCases {
+		t.Run(tc.format, func(t *testing.T) {
+			if got := timefmt.Describe(tc.format); got != tc.expected {
+				t.Errorf("expected: %q, got: %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestDescribeDirective(t *testing.T) {
+	descriptions := map[byte]string{'Y': "année", 'm': "mois", 'd': "jour"}
+	lc := &timefmt.Locale{
+		DescribeDirective: func(token timefmt.Token) string {
+			if description, ok := descriptions[token.Directive]; ok {
+				return description
+			}
+			return timefmt.DescribeDirective(token)
+		},
+	}
+	if got, expected := lc.Describe("%d/%m/%Y %H"), `jour, "/", mois, "/", année, hour`; got != expected {
+		t.Errorf("expected: %q, got: %q", expected, got)
+	}
+	if got, expected := timefmt.Describe("%d/%m/%Y"), `day of month, "/", month, "/", year`; got != expected {
+		t.Errorf("expected: %q, got: %q", expected, got)
+	}
+}
+
+func TestExample(t *testing.T) {
+	if got, expected := timefmt.Example("%F %T", time.Time{}), "2020-07-24 21:07:29"; got != expected {
+		t.Errorf("expected: %q, got: %q", expected, got)
+	}
+	tm := time.Date(2020, time.January, 2, 3, 4, 5, 0, time.UTC)
+	if got, expected := timefmt.Example("%F %T", tm), "2020-01-02 03:04:05"; got != expected {
+		t.Errorf("expected: %q, got: %q", expected, got)
+	}
+	for _, info := range timefmt.Directives() {
+		format := "%" + string(info.Directive)
+		if example := timefmt.Example(format, time.Time{}); info.Placeholder != "" && info.Numeric &&
+			!strings.ContainsAny(format, "fs") && len(example) != len(info.Placeholder) {
+			t.Errorf("expected the example %q of %s to be as long as %q", example, format, info.Placeholder)
+		}
+	}
+}
+
+func ExamplePlaceholder() {
+	fmt.Println(timefmt.Placeholder("%Y-%m-%d %H:%M", timefmt.PlaceholderLetters))
+	fmt.Println(timefmt.Placeholder("%Y-%m-%d %H:%M", timefmt.PlaceholderMask))
+	// Output:
+	// YYYY-MM-DD hh:mm
+	// ____-__-__ __:__
+}
+
+func ExampleDescribe() {
+	fmt.Println(timefmt.Describe("%a, %d %b %Y"))
+	// Output:
+	// abbreviated weekday name, ", ", day of month, abbreviated month name, year
+}
+
+func ExampleExample() {
+	fmt.Println(timefmt.Example("%a, %d %b %Y %I:%M %p", time.Time{}))
+	// Output:
+	// Fri, 24 Jul 2020 09:07 PM
+}
@@ -0,0 +1,315 @@
+package timefmt
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// FormatDuration formats the duration using the format.
+//
+// The directives are %d (days), %H (hours), %M (minutes), %S (seconds),
+// %f (microseconds), %N (nanoseconds, the width specifies the precision),
+// and %+ (sign). The largest unit in the format represents the total amount,
+// and the other units represent the remainders; "%H:%M:%S" formats 123 hours
+// as "123:04:05". A negative duration is signed before the first unit or
+// fraction unless the format has %+, which emits the sign of the duration. The padding and
+// width modifiers are available as [AppendFormat].
+func FormatDuration(d time.Duration, format string) string {
+	return string(AppendFormatDuration(make([]byte, 0, 32), d, format))
+}
+
+// AppendFormatDuration appends formatted duration string to the buffer.
+func AppendFormatDuration(buf []byte, d time.Duration, format string) []byte {
+	unit, signed := scanDurationFormat(format)
+	neg := d < 0
+	var u uint64
+	if neg {
+		u = uint64(-(d + 1)) + 1
+	} else {
+		u = uint64(d)
+	}
+	for i := 0; i < len(format); i++ {
+		b := format[i]
+		if b != '%' || i+1 == len(format) {
+			buf = append(buf, b)
+			continue
+		}
+		j, width, padding := i, 0, byte('0')
+	L:
+		for i++; i < len(format); i++ {
+			switch b = format[i]; b {
+			case '-':
+				padding = ^paddingMask
+			case '_':
+				padding = ' ' | ^paddingMask
+			case '0':
+				padding = '0' | ^paddingMask
+			case '^', '#':
+			case '1', '2', '3', '4', '5', '6', '7', '8', '9':
+				width = int(b & 0x0F)
+				for ; i+1 < len(format) && '0' <= format[i+1] && format[i+1] <= '9'; i++ {
+					width = min(width*10+int(format[i+1]&0x0F), 1024)
+				}
+				if padding == ^paddingMask {
+					padding = ' ' | ^paddingMask
+				}
+			default:
+				break L
+			}
+		}
+		if i == len(format) {
+			buf = append(buf, format[j:]...)
+			break
+		}
+		if !signed && neg && strings.IndexByte("dHMSfN", b) >= 0 {
+			buf, signed = append(buf, '-'), true
+		}
+		switch b {
+		case 'd', 'H', 'M', 'S':
+			value := durationValue(u, b, unit)
+			if b == 'd' {
+				buf = appendInt64(buf, value, width, padding)
+			} else {
+				buf = appendInt64(buf, value, max(width, 2), padding)
+			}
+		case 'f':
+			buf = appendInt(buf, int(u%uint64(time.Second)/uint64(time.Microsecond)), or(width, 6), padding)
+		case 'N':
+			nanos, digits := int(u%uint64(time.Second)), or(width, 9)
+			for k := digits; k < 9; k++ {
+				nanos /= 10
+			}
+			buf = appendInt(buf, nanos, min(digits, 9), '0')
+			for k := 9; k < digits; k++ {
+				buf = append(buf, '0')
+			}
+		case '+':
+			if neg {
+				buf = append(buf, '-')
+			} else {
+				buf = append(buf, '+')
+			}
+			signed = true
+		case 't':
+			buf = append(buf, '\t')
+		case 'n':
+			buf = append(buf, '\n')
+		case '%':
+			buf = append(buf, '%')
+		default:
+			buf = append(buf, format[j:i+1]...)
+		}
+	}
+	return buf
+}
+
+// ParseDuration parses duration string using the format.
+// See [FormatDuration] for the directives. The padding and width modifiers
+// are accepted, so the durations formatted by the same format are parsed.
+func ParseDuration(source, format string) (d time.Duration, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("failed to parse %q with %q: %w", source, format, err)
+		}
+	}()
+	unit, signed := scanDurationFormat(format)
+	var total, fraction uint64
+	sign, j := 1, 0
+	for i := 0; i < len(format); i++ {
+		b := format[i]
+		if b != '%' {
+			if j >= len(source) || source[j] != b {
+				err = expectedFormatError(b)
+				return
+			}
+			j++
+			continue
+		}
+		width, padding := 0, byte('0')
+	L:
+		for i++; i < len(format); i++ {
+			switch b = format[i]; b {
+			case '-':
+				padding = ^paddingMask
+			case '_':
+				padding = ' ' | ^paddingMask
+			case '0':
+				padding = '0' | ^paddingMask
+			case '^', '#':
+			case '1', '2', '3', '4', '5', '6', '7', '8', '9':
+				width = int(b & 0x0F)
+				for ; i+1 < len(format) && '0' <= format[i+1] && format[i+1] <= '9'; i++ {
+					width = min(width*10+int(format[i+1]&0x0F), 1024)
+				}
+				if padding == ^paddingMask {
+					padding = ' ' | ^paddingMask
+				}
+			default:
+				break L
+			}
+		}
+		if i == len(format) {
+			err = errors.New(`stray "%"`)
+			return
+		}
+		if !signed && strings.IndexByte("dHMSfN", b) >= 0 {
+			sign, j = parseSign(source, j, len(source))
+			signed = true
+		}
+		switch b {
+		case 'd', 'H', 'M', 'S':
+			if padding == ' '|^paddingMask {
+				j = skipSpaces(source, j)
+			}
+			var value int64
+			if b == unit {
+				if value, j, err = parseInt64(source, j, max(width, 19), b); err != nil {
+					return
+				}
+			} else {
+				var v int
+				if v, j, err = parseInt(source, j, max(width, 2), 0, durationModulo(b)-1, b); err != nil {
+					return
+				}
+				value = int64(v)
+			}
+			multiplier := uint64(durationUnitOf(b))
+			if uint64(value) > (math.MaxInt64-total)/multiplier {
+				err = errors.New("duration out of range")
+				return
+			}
+			total += uint64(value) * multiplier
+		case 'f':
+			if padding != '0' || width > 6 {
+				// the microseconds are padded as an integer
+				if padding == ' '|^paddingMask {
+					j = skipSpaces(source, j)
+				}
+				var value int
+				if value, j, err = parseInt(source, j, max(width, 6), 0, int(time.Second/time.Microsecond)-1, b); err != nil {
+					return
+				}
+				fraction = uint64(value) * uint64(time.Microsecond)
+				break
+			}
+			var value, k int
+			if value, k, err = parseInt(source, j, 6, 0, math.MaxInt, b); err != nil {
+				return
+			}
+			for digits := k - j; digits < 9; digits++ {
+				value *= 10
+			}
+			fraction, j = uint64(value), k
+		case 'N':
+			// the width is the precision, and the digits after nanoseconds are zeros
+			var value, k int
+			if value, k, err = parseInt(source, j, min(or(width, 9), 9), 0, math.MaxInt, b); err != nil {
+				return
+			}
+			for digits := k - j; digits < 9; digits++ {
+				value *= 10
+			}
+			for ; k < len(source) && k-j < width && source[k] == '0'; k++ {
+			}
+			fraction, j = uint64(value), k
+		case '+':
+			if j >= len(source) || source[j] != '+' && source[j] != '-' {
+				err = errors.New(`expected sign for "%+"`)
+				return
+			}
+			if source[j] == '-' {
+				sign = -1
+			}
+			j, signed = j+1, true
+		case 't', 'n':
+			k := j
+			if j = skipSpaces(source, j); k == j {
+				err = fmt.Errorf(`expected a space for "%%%c"`, b)
+				return
+			}
+		case '%':
+			if j >= len(source) || source[j] != b {
+				err = expectedFormatError(b)
+				return
+			}
+			j++
+		default:
+			err = fmt.Errorf(`unexpected format "%%%c"`, b)
+			return
+		}
+	}
+	if j < len(source) {
+		err = fmt.Errorf("unparsed string %q", source[j:])
+		return
+	}
+	if fraction > math.MaxInt64-total {
+		err = errors.New("duration out of range")
+		return
+	}
+	return time.Duration(sign) * time.Duration(total+fraction), nil
+}
+
+func skipSpaces(source string, index int) int {
+	for ; index < len(source); index++ {
+		if c := source[index]; c != ' ' && (c < '\t' || '\r' < c) {
+			break
+		}
+	}
+	return index
+}
+
+// scanDurationFormat returns the largest unit in the format, and reports
+// whether the format has the sign directive (%+).
+func scanDurationFormat(format string) (unit byte, sign bool) {
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		for i++; i < len(format); i++ {
+			if b := format[i]; b == 'd' || b == 'H' || b == 'M' || b == 'S' {
+				if unit == 0 || durationUnitOf(b) > durationUnitOf(unit) {
+					unit = b
+				}
+			} else if b == '+' {
+				sign = true
+			} else if b == '-' || b == '_' || b == '^' || b == '#' || '0' <= b && b <= '9' {
+				continue
+			}
+			break
+		}
+	}
+	return
+}
+
+func durationUnitOf(b byte) time.Duration {
+	switch b {
+	case 'd':
+		return 24 * time.Hour
+	case 'H':
+		return time.Hour
+	case 'M':
+		return time.Minute
+	default:
+		return time.Second
+	}
+}
+
+func durationModulo(b byte) int {
+	switch b {
+	case 'H':
+		return 24
+	default:
+		return 60
+	}
+}
+
+func durationValue(u uint64, b, unit byte) int64 {
+	value := u / uint64(durationUnitOf(b))
+	if b != unit {
+		value %= uint64(durationModulo(b))
+	}
+	return int64(value)
+}
@@ -0,0 +1,274 @@
+package timefmt_test
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+)
+
+var formatDurationTestCases = []struct {
+	format   string
+	d        time.Duration
+	expected string
+}{
+	{
+		format:   "%H:%M:%S",
+		d:        123*time.Hour + 4*time.Minute + 5*time.Second,
+		expected: "123:04:05",
+	},
+	{
+		format:   "%dd %Hh",
+		d:        123*time.Hour + 4*time.Minute + 5*time.Second,
+		expected: "5d 03h",
+	},
+	{
+		format:   "%-dd %-Hh %-Mm %-Ss",
+		d:        26*time.Hour + 4*time.Minute + 5*time.Second,
+		expected: "1d 2h 4m 5s",
+	},
+	{
+		format:   "%M:%S.%f",
+		d:        65*time.Minute + 5*time.Second + 123456789,
+		expected: "65:05.123456",
+	},
+	{
+		format:   "%S.%3N %S.%N %S.%12N",
+		d:        5*time.Second + 120456789,
+		expected: "05.120 05.120456789 05.120456789000",
+	},
+	{
+		format:   "%H:%M:%S",
+		d:        -(2*time.Hour + 30*time.Minute),
+		expected: "-02:30:00",
+	},
+	{
+		format:   "%+%H:%M %+%H:%M",
+		d:        2*time.Hour + 30*time.Minute,
+		expected: "+02:30 +02:30",
+	},
+	{
+		format:   "%+%H:%M",
+		d:        -(2*time.Hour + 30*time.Minute),
+		expected: "-02:30",
+	},
+	{
+		format:   "(%_4H|%04M|%3S)",
+		d:        2*time.Hour + 30*time.Minute,
+		expected: "(   2|0030|000)",
+	},
+	{
+		format:   "%S",
+		d:        -time.Second / 2,
+		expected: "-00",
+	},
+	{
+		format:   "%H:%M:%S.%N",
+		d:        time.Duration(-1 << 63),
+		expected: "-2562047:47:16.854775808",
+	},
+	{
+		format:   "%H %+",
+		d:        -time.Hour,
+		expected: "01 -",
+	},
+	{
+		format:   "%f %3N",
+		d:        -1500 * time.Millisecond,
+		expected: "-500000 500",
+	},
+	{
+		format:   "%%%t%n%Y%-",
+		d:        time.Hour,
+		expected: "%\t\n%Y%-",
+	},
+}
+
+func TestFormatDuration(t *testing.T) {
+	for _, tc := range formatDurationTestCases {
+		t.Run(tc.expected+"/"+tc.format, func(t *testing.T) {
+			got := timefmt.FormatDuration(tc.d, tc.format)
+			if got != tc.expected {
+				t.Error(diff(tc.expected, got))
+			}
+		})
+	}
+}
+
+var parseDurationTestCases = []struct {
+	source   string
+	format   string
+	d        time.Duration
+	parseErr error
+}{
+	{
+		source: "123:04:05",
+		format: "%H:%M:%S",
+		d:      123*time.Hour + 4*time.Minute + 5*time.Second,
+	},
+	{
+		source: "5d 3h",
+		format: "%dd %Hh",
+		d:      123 * time.Hour,
+	},
+	{
+		source: "-02:30:00",
+		format: "%H:%M:%S",
+		d:      -(2*time.Hour + 30*time.Minute),
+	},
+	{
+		source: "-02:30",
+		format: "%+%H:%M",
+		d:      -(2*time.Hour + 30*time.Minute),
+	},
+	{
+		source: "+02:30",
+		format: "%+%H:%M",
+		d:      2*time.Hour + 30*time.Minute,
+	},
+	{
+		source: "65:05.123",
+		format: "%M:%S.%f",
+		d:      65*time.Minute + 5*time.Second + 123*time.Millisecond,
+	},
+	{
+		source: "5.000000007",
+		format: "%S.%N",
+		d:      5*time.Second + 7,
+	},
+	{
+		source: "1%\t2",
+		format: "%H%%%t%M",
+		d:      time.Hour + 2*time.Minute,
+	},
+	{
+		source: "1d 2h 4m 5s",
+		format: "%-dd %-Hh %-Mm %-Ss",
+		d:      26*time.Hour + 4*time.Minute + 5*time.Second,
+	},
+	{
+		source: "(   2|0030|000)",
+		format: "(%_4H|%04M|%3S)",
+		d:      2*time.Hour + 30*time.Minute,
+	},
+	{
+		source: "-  2:05",
+		format: "%_3H:%M",
+		d:      -(2*time.Hour + 5*time.Minute),
+	},
+	{
+		source: "05.120 05.120456789000",
+		format: "%S.%3N %M.%12N",
+		d:      5*time.Minute + 5*time.Second + 120456789,
+	},
+	{
+		source: "5.123 6.00000123",
+		format: "%-S.%-f %-M.%8f",
+		d:      6*time.Minute + 5*time.Second + 123*time.Microsecond,
+	},
+	{
+		source: "5.   123",
+		format: "%S.%_6f",
+		d:      5*time.Second + 123*time.Microsecond,
+	},
+	{
+		source: "01 -",
+		format: "%H %+",
+		d:      -time.Hour,
+	},
+	{
+		source: "-500000",
+		format: "%f",
+		d:      -500 * time.Millisecond,
+	},
+	{
+		source:   "10:60",
+		format:   "%M:%S",
+		parseErr: errors.New(`cannot parse "%S"`),
+	},
+	{
+		source:   "1d 24h",
+		format:   "%dd %Hh",
+		parseErr: errors.New(`cannot parse "%H"`),
+	},
+	{
+		source:   "02:30",
+		format:   "%+%H:%M",
+		parseErr: errors.New(`expected sign for "%+"`),
+	},
+	{
+		source:   "3000000:00:00",
+		format:   "%H:%M:%S",
+		parseErr: errors.New("duration out of range"),
+	},
+	{
+		source:   "1:00:00x",
+		format:   "%H:%M:%S",
+		parseErr: errors.New(`unparsed string "x"`),
+	},
+	{
+		source:   "1",
+		format:   "%Y",
+		parseErr: errors.New(`unexpected format "%Y"`),
+	},
+	{
+		source:   "05.1204",
+		format:   "%S.%3N",
+		parseErr: errors.New(`unparsed string "4"`),
+	},
+	{
+		source:   "1",
+		format:   "%H%-",
+		parseErr: errors.New(`stray "%"`),
+	},
+	{
+		source:   "1",
+		format:   "%H%",
+		parseErr: errors.New(`stray "%"`),
+	},
+}
+
+func TestParseDuration(t *testing.T) {
+	for _, tc := range parseDurationTestCases {
+		t.Run(tc.source+"/"+tc.format, func(t *testing.T) {
+			got, err := timefmt.ParseDuration(tc.source, tc.format)
+			if tc.parseErr == nil {
+				if err != nil {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				if got != tc.d {
+					t.Errorf("expected: %v, got: %v", tc.d, got)
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("expected error %v but got: %v", tc.parseErr, err)
+				}
+				if !strings.Contains(err.Error(), tc.parseErr.Error()) {
+					t.Errorf("expected: %v, got: %v", tc.parseErr, err)
+				}
+			}
+		})
+	}
+}
+
+func ExampleFormatDuration() {
+	d := 123*time.Hour + 4*time.Minute + 5*time.Second
+	fmt.Println(timefmt.FormatDuration(d, "%H:%M:%S"))
+	fmt.Println(timefmt.FormatDuration(d, "%dd %Hh"))
+	// Output:
+	// 123:04:05
+	// 5d 03h
+}
+
+func ExampleParseDuration() {
+	d, err := timefmt.ParseDuration("123:04:05", "%H:%M:%S")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(d)
+	// Output: 123h4m5s
+}
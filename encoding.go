@@ -0,0 +1,146 @@
+package timefmt
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// FormatSpec specifies the format of [Time]. Implement it on an empty struct.
+//
+//	type DateTime struct{}
+//
+//	func (DateTime) Format() string { return "%Y/%m/%d %H:%M" }
+type FormatSpec interface {
+	Format() string
+}
+
+// LocationSpec is an optional interface of [FormatSpec] to specify the location.
+// The time is formatted in the location, and the time string without time zone
+// is parsed in the location. The default location is UTC.
+type LocationSpec interface {
+	Location() *time.Location
+}
+
+// Time is a [time.Time] encoded to and decoded from the format of the spec.
+// It implements [json.Marshaler], [json.Unmarshaler], [encoding.TextMarshaler],
+// [encoding.TextAppender], [encoding.TextUnmarshaler], [fmt.Stringer],
+// [database/sql.Scanner] and [driver.Valuer]. The zero time is encoded as null
+// in JSON and SQL, and as an empty string in text.
+type Time[F FormatSpec] struct {
+	time.Time
+}
+
+func (t Time[F]) spec() (string, *time.Location) {
+	var f F
+	if l, ok := any(f).(LocationSpec); ok {
+		return f.Format(), l.Location()
+	}
+	return f.Format(), nil
+}
+
+func (t Time[F]) appendFormat(buf []byte) []byte {
+	format, loc := t.spec()
+	u := t.Time
+	if loc != nil {
+		u = u.In(loc)
+	}
+	return AppendFormat(buf, u, format)
+}
+
+func (t *Time[F]) parse(source string) error {
+	format, loc := t.spec()
+	if loc == nil {
+		loc = time.UTC
+	}
+	u, err := ParseInLocation(source, format, loc)
+	if err != nil {
+		return err
+	}
+	t.Time = u
+	return nil
+}
+
+// MarshalJSON implements [json.Marshaler].
+func (t Time[F]) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	buf := t.appendFormat(append(make([]byte, 0, 64), '"'))
+	for _, b := range buf[1:] {
+		if b < ' ' || b == '"' || b == '\\' || b >= 0x80 {
+			return json.Marshal(string(buf[1:]))
+		}
+	}
+	return append(buf, '"'), nil
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+func (t *Time[F]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		t.Time = time.Time{}
+		return nil
+	}
+	var source string
+	if err := json.Unmarshal(data, &source); err != nil {
+		return err
+	}
+	return t.parse(source)
+}
+
+// AppendText implements [encoding.TextAppender].
+func (t Time[F]) AppendText(buf []byte) ([]byte, error) {
+	if t.IsZero() {
+		return buf, nil
+	}
+	return t.appendFormat(buf), nil
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (t Time[F]) MarshalText() ([]byte, error) {
+	return t.AppendText(make([]byte, 0, 64))
+}
+
+// String returns the time formatted in the format of the spec, or an empty
+// string for the zero time.
+func (t Time[F]) String() string {
+	buf, _ := t.AppendText(make([]byte, 0, 64))
+	return string(buf)
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+func (t *Time[F]) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		t.Time = time.Time{}
+		return nil
+	}
+	return t.parse(string(data))
+}
+
+// Scan implements [database/sql.Scanner]. It accepts a string, a byte slice,
+// and a [time.Time] for the drivers which convert the column to time.
+func (t *Time[F]) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		t.Time = time.Time{}
+		return nil
+	case string:
+		return t.parse(src)
+	case []byte:
+		return t.parse(string(src))
+	case time.Time:
+		t.Time = src
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into timefmt.Time", src)
+	}
+}
+
+// Value implements [driver.Valuer].
+func (t Time[F]) Value() (driver.Value, error) {
+	if t.IsZero() {
+		return nil, nil
+	}
+	return string(t.appendFormat(make([]byte, 0, 64))), nil
+}
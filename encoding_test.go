@@ -0,0 +1,159 @@
+package timefmt_test
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+)
+
+type dateTimeSpec struct{}
+
+func (dateTimeSpec) Format() string { return "%Y/%m/%d %H:%M" }
+
+type jstSpec struct{}
+
+func (jstSpec) Format() string { return "%Y-%m-%d %H:%M:%S" }
+
+func (jstSpec) Location() *time.Location { return time.FixedZone("JST", 9*60*60) }
+
+type quoteSpec struct{}
+
+func (quoteSpec) Format() string { return `"%Y"\%m` }
+
+func TestTimeJSON(t *testing.T) {
+	type record struct {
+		Created timefmt.Time[dateTimeSpec]  `json:"created"`
+		Updated timefmt.Time[dateTimeSpec]  `json:"updated"`
+		Local   timefmt.Time[jstSpec]       `json:"local"`
+		Deleted *timefmt.Time[dateTimeSpec] `json:"deleted,omitempty"`
+		Quoted  timefmt.Time[quoteSpec]     `json:"quoted"`
+	}
+	r := record{
+		Created: timefmt.Time[dateTimeSpec]{time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC)},
+		Local:   timefmt.Time[jstSpec]{time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC)},
+		Quoted:  timefmt.Time[quoteSpec]{time.Date(2020, time.July, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	bs, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	expected := `{"created":"2020/07/24 09:07","updated":null,` +
+		`"local":"2020-07-24 09:00:00","quoted":"\"2020\"\\07"}`
+	if got := string(bs); got != expected {
+		t.Errorf("expected: %s, got: %s", expected, got)
+	}
+	var got record
+	if err := json.Unmarshal(bs, &got); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if expected := r.Created.Truncate(time.Minute); !got.Created.Equal(expected) {
+		t.Errorf("expected: %v, got: %v", expected, got.Created)
+	}
+	if !got.Updated.IsZero() {
+		t.Errorf("expected zero time but got: %v", got.Updated)
+	}
+	if !got.Local.Equal(r.Local.Time) {
+		t.Errorf("expected: %v, got: %v", r.Local, got.Local)
+	}
+	if !got.Quoted.Equal(r.Quoted.Time) {
+		t.Errorf("expected: %v, got: %v", r.Quoted, got.Quoted)
+	}
+	err = json.Unmarshal([]byte(`{"created":"2020-07-24"}`), &got)
+	if expected := `failed to parse "2020-07-24" with "%Y/%m/%d %H:%M"`; err == nil ||
+		!strings.Contains(err.Error(), expected) {
+		t.Errorf("expected error %q but got: %v", expected, err)
+	}
+	err = json.Unmarshal([]byte(`{"created":2020}`), &got)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+	}
+}
+
+func TestTimeText(t *testing.T) {
+	tm := timefmt.Time[dateTimeSpec]{time.Date(2020, time.July, 24, 9, 7, 0, 0, time.UTC)}
+	bs, err := tm.MarshalText()
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if expected := "2020/07/24 09:07"; string(bs) != expected {
+		t.Errorf("expected: %q, got: %q", expected, string(bs))
+	}
+	var got timefmt.Time[dateTimeSpec]
+	if err := got.UnmarshalText(bs); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if !got.Equal(tm.Time) {
+		t.Errorf("expected: %v, got: %v", tm, got)
+	}
+	if bs, _ := (timefmt.Time[dateTimeSpec]{}).MarshalText(); len(bs) != 0 {
+		t.Errorf("expected empty text but got: %q", string(bs))
+	}
+	if err := got.UnmarshalText(nil); err != nil || !got.IsZero() {
+		t.Errorf("expected zero time but got: %v, %v", got, err)
+	}
+	var appender encoding.TextAppender = tm
+	if bs, err := appender.AppendText([]byte("at ")); err != nil || string(bs) != "at 2020/07/24 09:07" {
+		t.Errorf("expected: %q, got: %q, %v", "at 2020/07/24 09:07", string(bs), err)
+	}
+	if got, expected := fmt.Sprint(tm), "2020/07/24 09:07"; got != expected {
+		t.Errorf("expected: %q, got: %q", expected, got)
+	}
+}
+
+func TestTimeSQL(t *testing.T) {
+	tm := timefmt.Time[jstSpec]{time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC)}
+	v, err := tm.Value()
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if expected := "2020-07-24 09:00:00"; v != expected {
+		t.Errorf("expected: %q, got: %v", expected, v)
+	}
+	if v, _ := (timefmt.Time[jstSpec]{}).Value(); v != nil {
+		t.Errorf("expected nil but got: %v", v)
+	}
+	for _, src := range []any{"2020-07-24 09:00:00", []byte("2020-07-24 09:00:00"), tm.Time} {
+		var got timefmt.Time[jstSpec]
+		if err := got.Scan(src); err != nil {
+			t.Fatalf("expected no error but got: %v", err)
+		}
+		if !got.Equal(tm.Time) {
+			t.Errorf("expected: %v, got: %v", tm, got)
+		}
+	}
+	var got timefmt.Time[jstSpec]
+	if err := got.Scan(nil); err != nil || !got.IsZero() {
+		t.Errorf("expected zero time but got: %v, %v", got, err)
+	}
+	if err := got.Scan(20200724); err == nil ||
+		err.Error() != "cannot scan int into timefmt.Time" {
+		t.Errorf("expected error but got: %v", err)
+	}
+}
+
+func ExampleTime() {
+	var v struct {
+		Created timefmt.Time[dateTimeSpec] `json:"created"`
+		Deleted timefmt.Time[dateTimeSpec] `json:"deleted"`
+	}
+	if err := json.Unmarshal([]byte(`{"created":"2020/07/24 09:07","deleted":null}`), &v); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(v.Created.Time)
+	fmt.Println(v.Deleted.IsZero())
+	bs, err := json.Marshal(v)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(string(bs))
+	// Output:
+	// 2020-07-24 09:07:00 +0000 UTC
+	// true
+	// {"created":"2020/07/24 09:07","deleted":null}
+}
@@ -0,0 +1,174 @@
+package timefmt
+
+import (
+	"strings"
+	"time"
+)
+
+// Match represents a time string found in a text.
+type Match struct {
+	Start int
+	End   int
+	Time  time.Time
+}
+
+// FindAll finds all the time strings of the format in the text. The time
+// strings are looked up at the boundaries of digits and letters, and do not
+// overlap with each other.
+func FindAll(text, format string) []Match {
+	return FindAllInLocation(text, format, time.UTC)
+}
+
+// FindAllInLocation finds all the time strings of the format in the text,
+// parsing them with the default location.
+func FindAllInLocation(text, format string, loc *time.Location) []Match {
+	f := newFinder(format, loc)
+	var matches []Match
+	for i := f.next(text, 0); i < len(text); i = f.next(text, i+1) {
+		if t, n, ok := f.match(text, i); ok {
+			matches = append(matches, Match{i, i + n, t})
+			i += n - 1
+		}
+	}
+	return matches
+}
+
+// finder matches the time strings of the format. It filters the candidates
+// by the literal strings and the character classes of the directives before
+// parsing them.
+type finder struct {
+	format string
+	loc    *time.Location
+	elems  []findElem
+}
+
+type findElem struct {
+	kind     byte // 'l' for literal, 'd' for digits, 'a' for letters, 's' for spaces
+	literal  string
+	min, max int
+	sign     bool // allows a sign before the digits
+	space    bool // allows a space before the digits
+}
+
+func newFinder(format string, loc *time.Location) *finder {
+	f := &finder{format: format, loc: loc}
+	for _, token := range scanFormat(format) {
+		var elem findElem
+		info, ok := lookupDirective(token.Modifier, token.Directive)
+		switch {
+		case token.Directive == 0:
+			elem = findElem{kind: 'l', literal: token.Literal}
+		case !ok || !info.Parse:
+			// the fiscal directives are not supported by the parser
+			return f
+		case token.Directive == 'f':
+			elem = findElem{kind: 'd', min: 1, max: 9}
+		case token.Directive == 's':
+			elem = findElem{kind: 'd', min: 1, max: 19, sign: true}
+		case info.Numeric && info.Max > 0:
+			elem = findElem{kind: 'd', min: 1, max: info.digits(),
+				sign: info.Min < 0, space: spacePadded(token.Directive)}
+		case strings.IndexByte("BbhAapPZ", token.Directive) >= 0:
+			if n := len(f.elems); n > 0 && f.elems[n-1].kind == 'a' {
+				// adjacent names cannot be split by the character class
+				return f
+			}
+			elem = findElem{kind: 'a', min: 1}
+		case token.Directive == 't' || token.Directive == 'n':
+			elem = findElem{kind: 's', min: 1}
+		default:
+			// the other directives are verified only by the parser
+			return f
+		}
+		f.elems = append(f.elems, elem)
+	}
+	return f
+}
+
+// next returns the index of the next candidate of the time string, which
+// starts with the first literal string or the character class.
+func (f *finder) next(s string, i int) int {
+	if len(f.elems) == 0 || i >= len(s) {
+		return i
+	}
+	switch elem := f.elems[0]; elem.kind {
+	case 'l':
+		if j := strings.Index(s[i:], elem.literal); j >= 0 {
+			return i + j
+		}
+		return len(s)
+	case 'd':
+		if elem.sign || elem.space {
+			return i
+		}
+		for ; i < len(s); i++ {
+			if isDigit(s[i]) && (i == 0 || !isDigit(s[i-1])) {
+				break
+			}
+		}
+	case 'a':
+		for ; i < len(s); i++ {
+			if isLetter(s[i]) && (i == 0 || !isLetter(s[i-1])) {
+				break
+			}
+		}
+	}
+	return i
+}
+
+// match parses the time string at the index of the source.
+func (f *finder) match(s string, i int) (time.Time, int, bool) {
+	if i > 0 && !isBoundary(s[i-1], s[i]) || !f.prematch(s, i) {
+		return time.Time{}, 0, false
+	}
+	t, n, err := parsePrefix(s[i:], f.format, f.loc)
+	if err != nil || n == 0 || i+n < len(s) && !isBoundary(s[i+n-1], s[i+n]) {
+		return time.Time{}, 0, false
+	}
+	return t, n, true
+}
+
+// prematch reports whether the source at the index may match the format.
+func (f *finder) prematch(s string, i int) bool {
+	for _, elem := range f.elems {
+		switch elem.kind {
+		case 'l':
+			if !strings.HasPrefix(s[i:], elem.literal) {
+				return false
+			}
+			i += len(elem.literal)
+		case 'd':
+			if elem.sign && i < len(s) && (s[i] == '+' || s[i] == '-') ||
+				elem.space && i < len(s) && s[i] == ' ' {
+				i++
+			}
+			j := i
+			for j < len(s) && j-i < elem.max && isDigit(s[j]) {
+				j++
+			}
+			if j-i < elem.min {
+				return false
+			}
+			i = j
+		case 'a':
+			j := i
+			for j < len(s) && isLetter(s[j]) {
+				j++
+			}
+			if j == i {
+				return false
+			}
+			i = j
+		default:
+			j := i
+			for j < len(s) && (s[j] == ' ' || '\t' <= s[j] && s[j] <= '\r') {
+				j++
+			}
+			if j == i {
+				return false
+			}
+			i = j
+		}
+	}
+	return true
+}
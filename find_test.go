@@ -0,0 +1,131 @@
+package timefmt_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+)
+
+var findAllTestCases = []struct {
+	name     string
+	text     string
+	format   string
+	expected []timefmt.Match
+}{
+	{
+		name:   "date time",
+		text:   "error at 2020-07-24 09:07:29: connection refused (retry at 2020-07-24 09:08:00)",
+		format: "%Y-%m-%d %H:%M:%S",
+		expected: []timefmt.Match{
+			{9, 28, time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC)},
+			{59, 78, time.Date(2020, time.July, 24, 9, 8, 0, 0, time.UTC)},
+		},
+	},
+	{
+		name:   "boundaries",
+		text:   "12020-07-24 2020-07-241 2020-07-24",
+		format: "%F",
+		expected: []timefmt.Match{
+			{24, 34, time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC)},
+		},
+	},
+	{
+		name:   "invalid values",
+		text:   "2020-13-01 2020-07-32 2020-12-31",
+		format: "%Y-%m-%d",
+		expected: []timefmt.Match{
+			{22, 32, time.Date(2020, time.December, 31, 0, 0, 0, 0, time.UTC)},
+		},
+	},
+	{
+		name:   "names",
+		text:   "[Fri Jul 24 09:07:29 2020] started, [Sat Jul 25 00:00:00 2020] stopped",
+		format: "%c",
+		expected: []timefmt.Match{
+			{1, 25, time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC)},
+			{37, 61, time.Date(2020, time.July, 25, 0, 0, 0, 0, time.UTC)},
+		},
+	},
+	{
+		name:   "adjacent names",
+		text:   "FriJul 24",
+		format: "%a%b %d",
+		expected: []timefmt.Match{
+			{0, 9, time.Date(1900, time.July, 24, 0, 0, 0, 0, time.UTC)},
+		},
+	},
+	{
+		name:   "time zone",
+		text:   "24/Jul/2020:09:07:29 +0900 and 24/Jul/2020:09:07:29 Z",
+		format: "%d/%b/%Y:%T %z",
+		expected: []timefmt.Match{
+			{0, 26, time.Date(2020, time.July, 24, 9, 7, 29, 0, time.FixedZone("", 9*60*60))},
+			{31, 53, time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC)},
+		},
+	},
+	{
+		name:     "no match",
+		text:     "no timestamps here 12:34",
+		format:   "%H:%M:%S",
+		expected: nil,
+	},
+	{
+		name:     "empty",
+		text:     "",
+		format:   "%Y",
+		expected: nil,
+	},
+}
+
+func TestFindAll(t *testing.T) {
+	for _, tc := range findAllTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := timefmt.FindAll(tc.text, tc.format)
+			if len(got) != len(tc.expected) {
+				t.Fatalf("expected: %v, got: %v", tc.expected, got)
+			}
+			for i, m := range got {
+				if expected := tc.expected[i]; m.Start != expected.Start || m.End != expected.End ||
+					!m.Time.Equal(expected.Time) {
+					t.Errorf("expected: %v, got: %v", expected, m)
+				}
+				if _, err := timefmt.Parse(tc.text[m.Start:m.End], tc.format); err != nil {
+					t.Errorf("expected the match to be parsed but got: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestFindAllInLocation(t *testing.T) {
+	loc := time.FixedZone("JST", 9*60*60)
+	got := timefmt.FindAllInLocation("at 2020-07-24 09:07:29", "%Y-%m-%d %H:%M:%S", loc)
+	expected := []timefmt.Match{{3, 22, time.Date(2020, time.July, 24, 9, 7, 29, 0, loc)}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected: %v, got: %v", expected, got)
+	}
+}
+
+func ExampleFindAll() {
+	text := "2020-07-24 09:07:29 ERROR retry failed (last attempt at 2020-07-24 09:05:00)"
+	for _, m := range timefmt.FindAll(text, "%Y-%m-%d %H:%M:%S") {
+		fmt.Println(m.Start, m.End, m.Time)
+	}
+	// Output:
+	// 0 19 2020-07-24 09:07:29 +0000 UTC
+	// 56 75 2020-07-24 09:05:00 +0000 UTC
+}
+
+func BenchmarkFindAll(b *testing.B) {
+	text := strings.Repeat("2020-07-24 09:07:29 INFO request id=12345 took 67ms from 10.0.0.1\n", 16384)
+	b.SetBytes(int64(len(text)))
+	for b.Loop() {
+		if n := len(timefmt.FindAll(text, "%Y-%m-%d %H:%M:%S")); n != 16384 {
+			b.Fatalf("expected 16384 matches but got: %d", n)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package timefmt
+
+import "time"
+
+// FiscalCalendar represents a fiscal year calendar. The fiscal directives,
+// which are the alternative representations using the E modifier, are
+// available on formatting and parsing with the calendar.
+//
+//	%EY  fiscal year
+//	%Ey  fiscal year without century
+//	%Eq  fiscal quarter (1-4)
+//	%Em  fiscal period (01-12)
+//	%EV  fiscal week (01-53)
+type FiscalCalendar struct {
+	// StartMonth is the first month of the fiscal year (defaults to January).
+	StartMonth time.Month
+	// NameByEndYear names the fiscal year by the calendar year it ends in,
+	// instead of the year it starts in.
+	NameByEndYear bool
+	// Weeks configures a week-based calendar with the number of weeks of the
+	// periods in each quarter, like {4, 4, 5}. The fiscal year starts on the
+	// WeekStart day nearest to the first day of StartMonth, and the extra week
+	// of a 53-week year belongs to the last period.
+	Weeks [3]int
+	// WeekStart is the first day of the fiscal weeks of a week-based calendar.
+	WeekStart time.Weekday
+}
+
+// Format time to string using the format with fiscal directives.
+func (c *FiscalCalendar) Format(t time.Time, format string) string {
+	return string(c.AppendFormat(make([]byte, 0, 64), t, format))
+}
+
+// AppendFormat appends formatted time string with fiscal directives to the buffer.
+func (c *FiscalCalendar) AppendFormat(buf []byte, t time.Time, format string) []byte {
+	return appendFormat(buf, t, format, c, nil)
+}
+
+// Parse time string using the format with fiscal directives.
+func (c *FiscalCalendar) Parse(source, format string) (time.Time, error) {
+	return parse(source, format, time.UTC, time.Local, c, nil)
+}
+
+// ParseInLocation parses time string using the format with fiscal directives
+// with the default location.
+func (c *FiscalCalendar) ParseInLocation(source, format string, loc *time.Location) (time.Time, error) {
+	return parse(source, format, loc, loc, c, nil)
+}
+
+// Date returns the fiscal year, quarter, period and week of the time.
+func (c *FiscalCalendar) Date(t time.Time) (year, quarter, period, week int) {
+	y, m, d := t.Date()
+	date := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	if year = y; c.NameByEndYear && c.startMonth() > time.January {
+		year++
+	}
+	if start := c.YearStart(year, time.UTC); date.Before(start) {
+		year--
+	} else if !date.Before(c.YearStart(year+1, time.UTC)) {
+		year++
+	}
+	start := c.YearStart(year, time.UTC)
+	days := int(date.Sub(start).Hours()) / 24
+	week = days/7 + 1
+	if c.weekBased() {
+		for weeks := week; period < 12; period++ {
+			if weeks -= c.Weeks[period%3]; weeks <= 0 {
+				break
+			}
+		}
+		period = min(period+1, 12)
+	} else {
+		period = (int(m)-int(c.startMonth())+12)%12 + 1
+	}
+	quarter = (period + 2) / 3
+	return
+}
+
+// YearStart returns the first day of the fiscal year in the location.
+func (c *FiscalCalendar) YearStart(year int, loc *time.Location) time.Time {
+	if c.NameByEndYear && c.startMonth() > time.January {
+		year--
+	}
+	t := time.Date(year, c.startMonth(), 1, 0, 0, 0, 0, loc)
+	if c.weekBased() {
+		days := (int(c.WeekStart) - int(t.Weekday()) + 7) % 7
+		if days > 3 {
+			days -= 7
+		}
+		t = t.AddDate(0, 0, days)
+	}
+	return t
+}
+
+// calendarYear returns the calendar year of the date in the fiscal year. The
+// day can be zero for the month, and it reports false if the date does not
+// belong to the fiscal year.
+func (c *FiscalCalendar) calendarYear(fiscalYear int, month time.Month, day int) (int, bool) {
+	year := fiscalYear
+	if c.NameByEndYear && c.startMonth() > time.January {
+		year--
+	}
+	if month < c.startMonth() {
+		year++
+	}
+	if day == 0 {
+		return year, true
+	}
+	start, end := c.YearStart(fiscalYear, time.UTC), c.YearStart(fiscalYear+1, time.UTC)
+	for _, y := range []int{year, year + 1, year - 1} {
+		if date := time.Date(y, month, day, 0, 0, 0, 0, time.UTC); !date.Before(start) && date.Before(end) {
+			return y, true
+		}
+	}
+	return 0, false
+}
+
+// periodStart returns the first day of the fiscal period.
+func (c *FiscalCalendar) periodStart(year, period int, loc *time.Location) time.Time {
+	t := c.YearStart(year, loc)
+	if !c.weekBased() {
+		return t.AddDate(0, period-1, 0)
+	}
+	var weeks int
+	for i := 0; i < period-1; i++ {
+		weeks += c.Weeks[i%3]
+	}
+	return t.AddDate(0, 0, weeks*7)
+}
+
+func (c *FiscalCalendar) startMonth() time.Month {
+	if c.StartMonth < time.January || time.December < c.StartMonth {
+		return time.January
+	}
+	return c.StartMonth
+}
+
+func (c *FiscalCalendar) weekBased() bool {
+	return c.Weeks[0] > 0 && c.Weeks[1] > 0 && c.Weeks[2] > 0
+}
+
+func isFiscalDirective(b byte) bool {
+	_, ok := lookupDirective('E', b)
+	return ok
+}
+
+func (c *FiscalCalendar) appendDirective(buf []byte, t time.Time, b byte, width int, padding byte) []byte {
+	year, quarter, period, week := c.Date(t)
+	switch b {
+	case 'Y':
+		return appendInt(buf, year, or(width, 4), padding)
+	case 'y':
+		return appendInt(buf, abs(year%100), max(width, 2), padding)
+	case 'q':
+		return appendInt(buf, quarter, width, padding)
+	case 'm':
+		return appendInt(buf, period, max(width, 2), padding)
+	default:
+		return appendInt(buf, week, max(width, 2), padding)
+	}
+}
@@ -0,0 +1,271 @@
+package timefmt_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+)
+
+var (
+	fiscalApril   = &timefmt.FiscalCalendar{StartMonth: time.April}
+	fiscalOctober = &timefmt.FiscalCalendar{StartMonth: time.October, NameByEndYear: true}
+	fiscalRetail  = &timefmt.FiscalCalendar{
+		StartMonth: time.February, Weeks: [3]int{4, 5, 4}, WeekStart: time.Sunday,
+	}
+)
+
+var fiscalFormatTestCases = []struct {
+	cal      *timefmt.FiscalCalendar
+	format   string
+	t        time.Time
+	expected string
+}{
+	{
+		cal:      fiscalApril,
+		format:   "FY%EY Q%Eq P%Em W%EV %Ey %Y-%m-%d",
+		t:        time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+		expected: "FY2020 Q2 P04 W17 20 2020-07-24",
+	},
+	{
+		cal:      fiscalApril,
+		format:   "FY%EY Q%Eq P%Em W%EV",
+		t:        time.Date(2021, time.March, 31, 23, 59, 59, 0, time.UTC),
+		expected: "FY2020 Q4 P12 W53",
+	},
+	{
+		cal:      fiscalOctober,
+		format:   "FY%EY Q%Eq P%-Em W%_EV",
+		t:        time.Date(2020, time.October, 1, 0, 0, 0, 0, time.UTC),
+		expected: "FY2021 Q1 P1 W 1",
+	},
+	{
+		cal:      fiscalOctober,
+		format:   "FY%EY Q%Eq P%Em",
+		t:        time.Date(2020, time.September, 30, 0, 0, 0, 0, time.UTC),
+		expected: "FY2020 Q4 P12",
+	},
+	{
+		cal:      fiscalRetail,
+		format:   "FY%EY Q%Eq P%Em W%EV",
+		t:        time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+		expected: "FY2020 Q2 P06 W25",
+	},
+	{
+		cal:      fiscalRetail,
+		format:   "FY%EY Q%Eq P%Em W%EV",
+		t:        time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC),
+		expected: "FY2019 Q4 P12 W52",
+	},
+	{
+		cal:      fiscalRetail,
+		format:   "FY%EY Q%Eq P%Em W%EV",
+		t:        time.Date(2024, time.February, 3, 0, 0, 0, 0, time.UTC),
+		expected: "FY2023 Q4 P12 W53",
+	},
+	{
+		cal:      fiscalRetail,
+		format:   "FY%EY Q%Eq P%Em W%EV",
+		t:        time.Date(2021, time.January, 31, 0, 0, 0, 0, time.UTC),
+		expected: "FY2021 Q1 P01 W01",
+	},
+	{
+		cal:      fiscalApril,
+		format:   "%E %EZ %E",
+		t:        time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+		expected: "%E %EZ %E",
+	},
+}
+
+func TestFiscalCalendarFormat(t *testing.T) {
+	for _, tc := range fiscalFormatTestCases {
+		t.Run(tc.expected+"/"+tc.format, func(t *testing.T) {
+			got := tc.cal.Format(tc.t, tc.format)
+			if got != tc.expected {
+				t.Error(diff(tc.expected, got))
+			}
+		})
+	}
+}
+
+func TestFormatFiscalDirectives(t *testing.T) {
+	tm := time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC)
+	if got, expected := timefmt.Format(tm, "%EY %Eq"), "%EY %Eq"; got != expected {
+		t.Errorf("expected: %q, got: %q", expected, got)
+	}
+}
+
+var fiscalParseTestCases = []struct {
+	cal      *timefmt.FiscalCalendar
+	source   string
+	format   string
+	t        time.Time
+	parseErr error
+}{
+	{
+		cal:    fiscalApril,
+		source: "FY2020",
+		format: "FY%EY",
+		t:      time.Date(2020, time.April, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		cal:    fiscalApril,
+		source: "FY2020 Q4",
+		format: "FY%EY Q%Eq",
+		t:      time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		cal:    fiscalApril,
+		source: "FY20 P10 12:30",
+		format: "FY%Ey P%Em %H:%M",
+		t:      time.Date(2021, time.January, 1, 12, 30, 0, 0, time.UTC),
+	},
+	{
+		cal:    fiscalOctober,
+		source: "FY2021-P01",
+		format: "FY%EY-P%Em",
+		t:      time.Date(2020, time.October, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		cal:    fiscalRetail,
+		source: "FY2020 Q2",
+		format: "FY%EY Q%Eq",
+		t:      time.Date(2020, time.May, 3, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		cal:    fiscalRetail,
+		source: "FY2023 W53",
+		format: "FY%EY W%EV",
+		t:      time.Date(2024, time.January, 28, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		cal:    fiscalRetail,
+		source: "FY2020 W25 Fri",
+		format: "FY%EY W%EV %a",
+		t:      time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		cal:    fiscalApril,
+		source: "FY2020 W17 5",
+		format: "FY%EY W%EV %u",
+		t:      time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		cal:    fiscalApril,
+		source: "FY2020 2020-07-24",
+		format: "FY%EY %F",
+		t:      time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		cal:    fiscalApril,
+		source: "FY2021-05",
+		format: "FY%EY-%m",
+		t:      time.Date(2021, time.May, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		cal:    fiscalApril,
+		source: "FY2020 02/14",
+		format: "FY%EY %m/%d",
+		t:      time.Date(2021, time.February, 14, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		cal:    fiscalOctober,
+		source: "FY2021 Nov 3",
+		format: "FY%EY %b %e",
+		t:      time.Date(2020, time.November, 3, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		cal:    fiscalOctober,
+		source: "FY2021 Sep 30",
+		format: "FY%EY %b %e",
+		t:      time.Date(2021, time.September, 30, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		cal:    fiscalRetail,
+		source: "FY2020-07-24",
+		format: "FY%EY-%m-%d",
+		t:      time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		cal:      fiscalRetail,
+		source:   "FY2020-02-01",
+		format:   "FY%EY-%m-%d",
+		parseErr: errors.New(`date does not belong to the fiscal year of "%EY"`),
+	},
+	{
+		cal:      fiscalApril,
+		source:   "FY2020 123",
+		format:   "FY%EY %j",
+		parseErr: errors.New(`use "%m" to parse day of fiscal year`),
+	},
+	{
+		cal:      fiscalApril,
+		source:   "FY2020 24",
+		format:   "FY%EY %d",
+		parseErr: errors.New(`use "%m" to parse day of fiscal year`),
+	},
+	{
+		cal:      fiscalApril,
+		source:   "Q3",
+		format:   "Q%Eq",
+		parseErr: errors.New(`use "%EY" to parse fiscal quarter, period or week`),
+	},
+	{
+		cal:      fiscalApril,
+		source:   "FY2020 P13",
+		format:   "FY%EY P%Em",
+		parseErr: errors.New(`cannot parse "%Em"`),
+	},
+	{
+		cal:      fiscalApril,
+		source:   "FY2020",
+		format:   "FY%EZ",
+		parseErr: errors.New(`unexpected format "%E"`),
+	},
+}
+
+func TestFiscalCalendarParse(t *testing.T) {
+	for _, tc := range fiscalParseTestCases {
+		t.Run(tc.source+"/"+tc.format, func(t *testing.T) {
+			got, err := tc.cal.Parse(tc.source, tc.format)
+			if tc.parseErr == nil {
+				if err != nil {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				if !got.Equal(tc.t) {
+					t.Errorf("expected: %v, got: %v", tc.t, got)
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("expected error %v but got: %v", tc.parseErr, err)
+				}
+				if !strings.Contains(err.Error(), tc.parseErr.Error()) {
+					t.Errorf("expected: %v, got: %v", tc.parseErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestFiscalCalendarRoundTrip(t *testing.T) {
+	for _, cal := range []*timefmt.FiscalCalendar{fiscalApril, fiscalOctober, fiscalRetail} {
+		for tm := time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC); tm.Year() < 2026; tm = tm.AddDate(0, 0, 1) {
+			formats := []string{"%EY-%EV-%u", "%EY-%m-%d"}
+			if cal == fiscalRetail {
+				// the same date appears twice in the fiscal year of 53 weeks
+				formats = formats[:1]
+			}
+			for _, format := range formats {
+				got, err := cal.Parse(cal.Format(tm, format), format)
+				if err != nil {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				if !got.Equal(tm) {
+					t.Fatalf("expected: %v, got: %v", tm, got)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package timefmt
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// TimeValue is a time parsed by one of the formats, which implements
+// [flag.Value], [flag.Getter] and [encoding.TextUnmarshaler]. The formats are
+// tried in order, and the time string without time zone is parsed in the
+// location (defaults to UTC).
+//
+//	fs.Var(&timefmt.TimeValue{Time: &since, Formats: []string{"%FT%R", "%F"}},
+//		"since", "start time (format `%FT%R` or %F)")
+type TimeValue struct {
+	Time     *time.Time
+	Formats  []string
+	Location *time.Location
+}
+
+// Set parses the string and sets the time.
+func (v *TimeValue) Set(s string) error {
+	t, err := parseFormats(s, v.Formats, v.Location)
+	if err != nil {
+		return err
+	}
+	*v.Time = t
+	return nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+func (v *TimeValue) UnmarshalText(text []byte) error {
+	return v.Set(string(text))
+}
+
+// String returns the time formatted by the first format, or an empty string
+// for the zero time.
+func (v *TimeValue) String() string {
+	if v == nil || v.Time == nil || v.Time.IsZero() || len(v.Formats) == 0 {
+		return ""
+	}
+	return Format(*v.Time, v.Formats[0])
+}
+
+// Get implements [flag.Getter].
+func (v *TimeValue) Get() any {
+	return *v.Time
+}
+
+func parseFormats(s string, formats []string, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	for _, format := range formats {
+		t, err := ParseInLocation(s, format, loc)
+		if err == nil || len(formats) == 1 {
+			return t, err
+		}
+	}
+	return time.Time{}, fmt.Errorf("failed to parse %q with any of %q", s, formats)
+}
+
+// Flag defines a time flag with the name, format, default value and usage
+// in the flag set (defaults to [flag.CommandLine]), and returns the address
+// of the time. The format is shown in the help text as the argument name.
+func Flag(fs *flag.FlagSet, name, format string, value time.Time, usage string) *time.Time {
+	p := new(time.Time)
+	FlagVar(fs, p, name, format, value, usage)
+	return p
+}
+
+// FlagVar defines a time flag like [Flag], storing the time in p.
+func FlagVar(fs *flag.FlagSet, p *time.Time, name, format string, value time.Time, usage string) {
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+	*p = value
+	if !strings.Contains(usage, "`") {
+		usage += " (format `" + format + "`)"
+	}
+	fs.Var(&TimeValue{Time: p, Formats: []string{format}}, name, usage)
+}
+
+// LookupEnv parses the environment variable by one of the formats. The ok
+// reports whether the variable is present, like [os.LookupEnv].
+func LookupEnv(key string, formats ...string) (t time.Time, ok bool, err error) {
+	s, ok := os.LookupEnv(key)
+	if !ok {
+		return
+	}
+	if t, err = parseFormats(s, formats, nil); err != nil {
+		err = fmt.Errorf("$%s: %w", key, err)
+	}
+	return
+}
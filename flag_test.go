@@ -0,0 +1,117 @@
+package timefmt_test
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+)
+
+func TestFlag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	since := timefmt.Flag(fs, "since", "%Y-%m-%dT%H:%M", time.Time{}, "start time")
+	until := timefmt.Flag(fs, "until", "%Y-%m-%d", time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC), "end date")
+	if err := fs.Parse([]string{"-since", "2020-07-01T09:30"}); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if expected := time.Date(2020, time.July, 1, 9, 30, 0, 0, time.UTC); !since.Equal(expected) {
+		t.Errorf("expected: %v, got: %v", expected, *since)
+	}
+	if expected := time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC); !until.Equal(expected) {
+		t.Errorf("expected: %v, got: %v", expected, *until)
+	}
+	if got, expected := fs.Lookup("since").Value.(flag.Getter).Get(), *since; got != expected {
+		t.Errorf("expected: %v, got: %v", expected, got)
+	}
+
+	err := fs.Parse([]string{"-until", "07/24/2020"})
+	if expected := `invalid value "07/24/2020" for flag -until: ` +
+		`failed to parse "07/24/2020" with "%Y-%m-%d"`; err == nil || !strings.HasPrefix(err.Error(), expected) {
+		t.Errorf("expected error %q but got: %v", expected, err)
+	}
+}
+
+func TestFlagUsage(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var sb strings.Builder
+	fs.SetOutput(&sb)
+	timefmt.Flag(fs, "since", "%Y-%m-%dT%H:%M", time.Time{}, "start time")
+	timefmt.Flag(fs, "until", "%Y-%m-%d", time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC), "end `date`")
+	fs.PrintDefaults()
+	expected := `  -since %Y-%m-%dT%H:%M
+    	start time (format %Y-%m-%dT%H:%M)
+  -until date
+    	end date (default 2020-07-24)
+`
+	if got := sb.String(); got != expected {
+		t.Errorf("expected: %q, got: %q", expected, got)
+	}
+}
+
+func TestTimeValue(t *testing.T) {
+	var tm time.Time
+	loc := time.FixedZone("JST", 9*60*60)
+	v := &timefmt.TimeValue{Time: &tm, Formats: []string{"%Y-%m-%dT%H:%M", "%Y-%m-%d"}, Location: loc}
+	if err := v.Set("2020-07-24"); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if expected := time.Date(2020, time.July, 24, 0, 0, 0, 0, loc); !tm.Equal(expected) {
+		t.Errorf("expected: %v, got: %v", expected, tm)
+	}
+	if err := v.UnmarshalText([]byte("2020-07-24T09:30")); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if got, expected := v.String(), "2020-07-24T09:30"; got != expected {
+		t.Errorf("expected: %q, got: %q", expected, got)
+	}
+	err := v.Set("24/07/2020")
+	if expected := `failed to parse "24/07/2020" with any of ["%Y-%m-%dT%H:%M" "%Y-%m-%d"]`; err == nil ||
+		err.Error() != expected {
+		t.Errorf("expected error %q but got: %v", expected, err)
+	}
+	if got := (&timefmt.TimeValue{}).String(); got != "" {
+		t.Errorf("expected empty string but got: %q", got)
+	}
+}
+
+func TestLookupEnv(t *testing.T) {
+	t.Setenv("TIMEFMT_SINCE", "2020-07-24")
+	got, ok, err := timefmt.LookupEnv("TIMEFMT_SINCE", "%Y-%m-%dT%H:%M", "%Y-%m-%d")
+	if err != nil || !ok {
+		t.Fatalf("expected no error but got: %v, %v", ok, err)
+	}
+	if expected := time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC); !got.Equal(expected) {
+		t.Errorf("expected: %v, got: %v", expected, got)
+	}
+	if _, ok, err := timefmt.LookupEnv("TIMEFMT_UNSET", "%Y"); ok || err != nil {
+		t.Errorf("expected not found but got: %v, %v", ok, err)
+	}
+	t.Setenv("TIMEFMT_SINCE", "yesterday")
+	_, ok, err = timefmt.LookupEnv("TIMEFMT_SINCE", "%Y-%m-%d")
+	if expected := `$TIMEFMT_SINCE: failed to parse "yesterday" with "%Y-%m-%d"`; !ok || err == nil ||
+		!strings.HasPrefix(err.Error(), expected) {
+		t.Errorf("expected error %q but got: %v", expected, err)
+	}
+}
+
+func ExampleFlag() {
+	fs := flag.NewFlagSet("example", flag.ExitOnError)
+	fs.SetOutput(os.Stdout)
+	since := timefmt.Flag(fs, "since", "%Y-%m-%dT%H:%M", time.Time{}, "start time")
+	if err := fs.Parse([]string{"-since", "2020-07-24T09:00"}); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(*since)
+	fs.PrintDefaults()
+	// Output:
+	// 2020-07-24 09:00:00 +0000 UTC
+	//   -since %Y-%m-%dT%H:%M
+	//     	start time (format %Y-%m-%dT%H:%M)
+}
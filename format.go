@@ -12,272 +12,176 @@ func Format(t time.Time, format string) string {
 
 // AppendFormat appends formatted time string to the buffer.
 func AppendFormat(buf []byte, t time.Time, format string) []byte {
+	if buf, ok := appendPreset(buf, t, format); ok {
+		return buf
+	}
+	return appendFormat(buf, t, format, nil, nil)
+}
+
+func appendFormat(buf []byte, t time.Time, format string, cal *FiscalCalendar, lc *Locale) []byte {
 	year, month, day := t.Date()
 	hour, minute, second := t.Clock()
-	var width, colons int
-	var padding byte
-	var pending string
-	var upper, swap bool
-	for i := 0; i < len(format); i++ {
-		if b := format[i]; b == '%' {
-			if i++; i == len(format) {
-				buf = append(buf, '%')
+	tokens := tokenizer{format: format}
+	for tokens.next() {
+		token := &tokens.token
+		b, width, padding, upper, swap := token.Directive, token.Width, token.paddingByte(), token.Upper, token.Swap
+		if token.Modifier != 0 {
+			if cal != nil {
+				buf = cal.appendDirective(buf, t, b, width, padding)
+				continue
+			}
+			b = 0 // the fiscal directives are kept as they are without the calendar
+		}
+		switch b {
+		case 'Y':
+			buf = appendInt(buf, year, or(width, 4), padding)
+		case 'y':
+			buf = appendInt(buf, abs(year%100), max(width, 2), padding)
+		case 'C':
+			c := year / 100
+			z := year < 0 && c == 0
+			if z {
+				c = -1
+			}
+			buf = appendInt(buf, c, max(width, 2), padding)
+			if z {
+				buf[len(buf)-1] = '0'
+			}
+		case 'g':
+			year, _ := t.ISOWeek()
+			buf = appendInt(buf, abs(year%100), max(width, 2), padding)
+		case 'G':
+			year, _ := t.ISOWeek()
+			buf = appendInt(buf, year, or(width, 4), padding)
+		case 'm':
+			buf = appendInt(buf, int(month), max(width, 2), padding)
+		case 'q':
+			buf = appendInt(buf, (int(month)+2)/3, width, padding)
+		case 'Q':
+			buf = appendInt(buf, (int(month)+5)/6, width, padding)
+		case 'B':
+			buf = appendString(buf, longMonthNames[month-1], width, padding, upper, swap)
+		case 'b', 'h':
+			buf = appendString(buf, shortMonthNames[month-1], width, padding, upper, swap)
+		case 'A':
+			buf = appendString(buf, longWeekNames[t.Weekday()], width, padding, upper, swap)
+		case 'a':
+			buf = appendString(buf, shortWeekNames[t.Weekday()], width, padding, upper, swap)
+		case 'w':
+			buf = appendInt(buf, int(t.Weekday()), width, padding)
+		case 'u':
+			buf = appendInt(buf, or(int(t.Weekday()), 7), width, padding)
+		case 'V':
+			_, week := t.ISOWeek()
+			buf = appendInt(buf, week, max(width, 2), padding)
+		case 'U':
+			week := (t.YearDay() + 6 - int(t.Weekday())) / 7
+			buf = appendInt(buf, week, max(width, 2), padding)
+		case 'W':
+			week := t.YearDay()
+			if int(t.Weekday()) > 0 {
+				week -= int(t.Weekday()) - 7
+			}
+			week /= 7
+			buf = appendInt(buf, week, max(width, 2), padding)
+		case 'e':
+			if padding < ^paddingMask {
+				padding = ' '
+			}
+			fallthrough
+		case 'd':
+			buf = appendInt(buf, day, max(width, 2), padding)
+		case 'o':
+			buf = appendInt(buf, day, width, padding)
+			buf = appendString(buf, lc.ordinalSuffix(day), 0, padding, upper, swap)
+		case 'j':
+			buf = appendInt(buf, t.YearDay(), max(width, 3), padding)
+		case 'k':
+			if padding < ^paddingMask {
+				padding = ' '
+			}
+			fallthrough
+		case 'H':
+			buf = appendInt(buf, hour, max(width, 2), padding)
+		case 'l':
+			if padding < ^paddingMask {
+				padding = ' '
+			}
+			fallthrough
+		case 'I':
+			buf = appendInt(buf, or(hour%12, 12), max(width, 2), padding)
+		case 'P':
+			swap = !upper && !swap
+			fallthrough
+		case 'p':
+			if hour < 12 {
+				buf = appendString(buf, "AM", width, padding, upper, swap)
+			} else {
+				buf = appendString(buf, "PM", width, padding, upper, swap)
+			}
+		case 'M':
+			buf = appendInt(buf, minute, max(width, 2), padding)
+		case 'S':
+			buf = appendInt(buf, second, max(width, 2), padding)
+		case 's':
+			if padding < ^paddingMask {
+				padding = ' '
+			}
+			buf = appendInt64(buf, t.Unix(), width, padding)
+		case 'f':
+			buf = appendInt(buf, t.Nanosecond()/1000, or(width, 6), padding)
+		case 'Z', 'z':
+			name, offset := t.Zone()
+			if b == 'Z' && name != "" {
+				buf = appendString(buf, name, width, padding, upper, swap)
 				break
 			}
-			b, width, padding, upper, swap = format[i], 0, '0', false, false
-		L:
-			switch b {
-			case '-':
-				if pending != "" {
-					buf = append(buf, '-')
-					break
-				}
-				if i++; i == len(format) {
-					goto K
-				}
-				padding = ^paddingMask
-				b = format[i]
-				goto L
-			case '_':
-				if i++; i == len(format) {
-					goto K
-				}
-				padding = ' ' | ^paddingMask
-				b = format[i]
-				goto L
-			case '^':
-				if i++; i == len(format) {
-					goto K
-				}
-				upper = true
-				b = format[i]
-				goto L
-			case '#':
-				if i++; i == len(format) {
-					goto K
-				}
-				swap = true
-				b = format[i]
-				goto L
-			case '0':
-				if i++; i == len(format) {
-					goto K
-				}
-				padding = '0' | ^paddingMask
-				b = format[i]
-				goto L
-			case '1', '2', '3', '4', '5', '6', '7', '8', '9':
-				width = int(b & 0x0F)
-				for i++; i < len(format); i++ {
-					if b = format[i]; b <= '9' && '0' <= b {
-						width = min(width*10+int(b&0x0F), 1024)
-					} else {
-						break
-					}
-				}
-				if padding == ^paddingMask {
-					padding = ' ' | ^paddingMask
-				}
-				if i == len(format) {
-					goto K
-				}
-				goto L
-			case 'Y':
-				buf = appendInt(buf, year, or(width, 4), padding)
-			case 'y':
-				buf = appendInt(buf, abs(year%100), max(width, 2), padding)
-			case 'C':
-				c := year / 100
-				z := year < 0 && c == 0
-				if z {
-					c = -1
-				}
-				buf = appendInt(buf, c, max(width, 2), padding)
-				if z {
-					buf[len(buf)-1] = '0'
-				}
-			case 'g':
-				year, _ := t.ISOWeek()
-				buf = appendInt(buf, abs(year%100), max(width, 2), padding)
-			case 'G':
-				year, _ := t.ISOWeek()
-				buf = appendInt(buf, year, or(width, 4), padding)
-			case 'm':
-				buf = appendInt(buf, int(month), max(width, 2), padding)
-			case 'B':
-				buf = appendString(buf, longMonthNames[month-1], width, padding, upper, swap)
-			case 'b', 'h':
-				buf = appendString(buf, shortMonthNames[month-1], width, padding, upper, swap)
-			case 'A':
-				buf = appendString(buf, longWeekNames[t.Weekday()], width, padding, upper, swap)
-			case 'a':
-				buf = appendString(buf, shortWeekNames[t.Weekday()], width, padding, upper, swap)
-			case 'w':
-				buf = appendInt(buf, int(t.Weekday()), width, padding)
-			case 'u':
-				buf = appendInt(buf, or(int(t.Weekday()), 7), width, padding)
-			case 'V':
-				_, week := t.ISOWeek()
-				buf = appendInt(buf, week, max(width, 2), padding)
-			case 'U':
-				week := (t.YearDay() + 6 - int(t.Weekday())) / 7
-				buf = appendInt(buf, week, max(width, 2), padding)
-			case 'W':
-				week := t.YearDay()
-				if int(t.Weekday()) > 0 {
-					week -= int(t.Weekday()) - 7
-				}
-				week /= 7
-				buf = appendInt(buf, week, max(width, 2), padding)
-			case 'e':
-				if padding < ^paddingMask {
-					padding = ' '
-				}
-				fallthrough
-			case 'd':
-				buf = appendInt(buf, day, max(width, 2), padding)
-			case 'j':
-				buf = appendInt(buf, t.YearDay(), max(width, 3), padding)
-			case 'k':
-				if padding < ^paddingMask {
-					padding = ' '
+			i := len(buf)
+			if padding != ^paddingMask {
+				for ; width > 1; width-- {
+					buf = append(buf, padding&paddingMask)
 				}
-				fallthrough
-			case 'H':
-				buf = appendInt(buf, hour, max(width, 2), padding)
-			case 'l':
-				if padding < ^paddingMask {
-					padding = ' '
-				}
-				fallthrough
-			case 'I':
-				buf = appendInt(buf, or(hour%12, 12), max(width, 2), padding)
-			case 'P':
-				swap = !upper && !swap
-				fallthrough
-			case 'p':
-				if hour < 12 {
-					buf = appendString(buf, "AM", width, padding, upper, swap)
-				} else {
-					buf = appendString(buf, "PM", width, padding, upper, swap)
-				}
-			case 'M':
-				buf = appendInt(buf, minute, max(width, 2), padding)
-			case 'S':
-				buf = appendInt(buf, second, max(width, 2), padding)
-			case 's':
-				if padding < ^paddingMask {
-					padding = ' '
-				}
-				buf = appendInt64(buf, t.Unix(), width, padding)
-			case 'f':
-				buf = appendInt(buf, t.Nanosecond()/1000, or(width, 6), padding)
-			case 'Z', 'z':
-				name, offset := t.Zone()
-				if b == 'Z' && name != "" {
-					buf = appendString(buf, name, width, padding, upper, swap)
-					break
-				}
-				i := len(buf)
-				if padding != ^paddingMask {
-					for ; width > 1; width-- {
-						buf = append(buf, padding&paddingMask)
-					}
-				}
-				j := len(buf)
-				if offset < 0 {
-					buf = append(buf, '-')
-					offset = -offset
-				} else {
-					buf = append(buf, '+')
-				}
-				k := len(buf)
-				buf = appendInt(buf, offset/3600, 2, padding)
-				if buf[k] == ' ' {
-					buf[k-1], buf[k] = buf[k], buf[k-1]
-				}
-				if offset %= 3600; colons <= 2 || offset != 0 {
-					if colons != 0 {
-						buf = append(buf, ':')
-					}
-					buf = appendInt(buf, offset/60, 2, '0')
-					if offset %= 60; colons == 2 || colons == 3 && offset != 0 {
-						buf = append(buf, ':')
-						buf = appendInt(buf, offset, 2, '0')
-					}
-				}
-				colons = 0
-				if k = min(len(buf)-j-1, j-i); k > 0 {
-					copy(buf[j-k:], buf[j:])
-					buf = buf[:len(buf)-k]
-					if padding&paddingMask == '0' {
-						buf[i], buf[j-k] = buf[j-k], buf[i]
-					}
-				}
-			case ':':
-				if pending != "" {
+			}
+			j := len(buf)
+			if offset < 0 {
+				buf = append(buf, '-')
+				offset = -offset
+			} else {
+				buf = append(buf, '+')
+			}
+			k := len(buf)
+			buf = appendInt(buf, offset/3600, 2, padding)
+			if buf[k] == ' ' {
+				buf[k-1], buf[k] = buf[k], buf[k-1]
+			}
+			if offset %= 3600; token.Colons <= 2 || offset != 0 {
+				if token.Colons != 0 {
 					buf = append(buf, ':')
-				} else {
-					colons = 1
-				M:
-					for i++; i < len(format); i++ {
-						switch format[i] {
-						case ':':
-							colons++
-						case 'z':
-							if colons > 3 {
-								i++
-								break M
-							}
-							b = 'z'
-							goto L
-						default:
-							break M
-						}
-					}
-					buf = appendLast(buf, format[:i], width, padding)
-					i--
-					colons = 0
 				}
-			case 't':
-				buf = appendString(buf, "\t", width, padding, false, false)
-			case 'n':
-				buf = appendString(buf, "\n", width, padding, false, false)
-			case '%':
-				buf = appendString(buf, "%", width, padding, false, false)
-			case 'c':
-				pending, swap = "a b e H:M:S Y", false
-			case '+':
-				pending, swap = "a b e H:M:S Z Y", false
-			case 'v':
-				pending, swap = "e-b-Y", false
-			case 'r':
-				pending, swap = "I:M:S p", false
-			case 'F':
-				pending = "Y-m-d"
-			case 'D', 'x':
-				pending = "m/d/y"
-			case 'T', 'X':
-				pending = "H:M:S"
-			case 'R':
-				pending = "H:M"
-			default:
-				if pending == "" {
-					buf = appendLast(buf, format[:i], width-1, padding)
+				buf = appendInt(buf, offset/60, 2, '0')
+				if offset %= 60; token.Colons == 2 || token.Colons == 3 && offset != 0 {
+					buf = append(buf, ':')
+					buf = appendInt(buf, offset, 2, '0')
 				}
-				buf = append(buf, b)
 			}
-			if pending != "" {
-				b, pending, width, padding = pending[0], pending[1:], 0, '0'
-				goto L
+			if k = min(len(buf)-j-1, j-i); k > 0 {
+				copy(buf[j-k:], buf[j:])
+				buf = buf[:len(buf)-k]
+				if padding&paddingMask == '0' {
+					buf[i], buf[j-k] = buf[j-k], buf[i]
+				}
 			}
-		} else {
-			buf = append(buf, b)
+		case 't':
+			buf = appendString(buf, "\t", width, padding, false, false)
+		case 'n':
+			buf = appendString(buf, "\n", width, padding, false, false)
+		case '%':
+			buf = appendString(buf, "%", width, padding, false, false)
+		default: // the literal strings, and the unknown directives as they are
+			buf = appendString(buf, token.Literal, width, padding, false, false)
 		}
 	}
 	return buf
-K:
-	return appendLast(buf, format, width, padding)
 }
 
 const smalls = "" +
@@ -368,7 +272,7 @@ func appendString(buf []byte, str string, width int, padding byte, upper, swap b
 	}
 	switch {
 	case swap:
-		if str[1] < 'a' {
+		if len(str) > 1 && str[1] < 'a' {
 			for _, b := range []byte(str) {
 				buf = append(buf, b|0x20)
 			}
@@ -385,16 +289,6 @@ func appendString(buf []byte, str string, width int, padding byte, upper, swap b
 	return buf
 }
 
-func appendLast(buf []byte, format string, width int, padding byte) []byte {
-	for i := len(format) - 1; i >= 0; i-- {
-		if format[i] == '%' {
-			buf = appendString(buf, format[i:], width, padding, false, false)
-			break
-		}
-	}
-	return buf
-}
-
 func or(x, y int) int {
 	if x != 0 {
 		return x
@@ -411,6 +305,22 @@ func abs(x int) int {
 
 const paddingMask byte = 0x7F
 
+// OrdinalSuffix returns the English suffix of the day of month for the ordinal
+// directive (%o). Use [Locale] to support ordinals of other languages.
+func OrdinalSuffix(n int) string {
+	if n%100/10 != 1 {
+		switch n % 10 {
+		case 1:
+			return "st"
+		case 2:
+			return "nd"
+		case 3:
+			return "rd"
+		}
+	}
+	return "th"
+}
+
 var longMonthNames = []string{
 	"January",
 	"February",
@@ -189,6 +189,81 @@ var formatTestCases = []struct {
 		t:        time.Date(2020, time.January, 9, 0, 0, 0, 0, time.UTC),
 		expected: " 9 9  9    9 0009",
 	},
+	{
+		format:   "%o %-o %_3o %03o %^o %#o",
+		t:        time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+		expected: "1st 1st   1st 001st 1ST 1ST",
+	},
+	{
+		format:   "%o %d",
+		t:        time.Date(2020, time.July, 2, 0, 0, 0, 0, time.UTC),
+		expected: "2nd 02",
+	},
+	{
+		format:   "%B %o, %Y",
+		t:        time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+		expected: "July 24th, 2020",
+	},
+	{
+		format:   "%o %d",
+		t:        time.Date(2020, time.July, 3, 0, 0, 0, 0, time.UTC),
+		expected: "3rd 03",
+	},
+	{
+		format:   "%o %d",
+		t:        time.Date(2020, time.July, 11, 0, 0, 0, 0, time.UTC),
+		expected: "11th 11",
+	},
+	{
+		format:   "%o %d",
+		t:        time.Date(2020, time.July, 12, 0, 0, 0, 0, time.UTC),
+		expected: "12th 12",
+	},
+	{
+		format:   "%o %d",
+		t:        time.Date(2020, time.July, 13, 0, 0, 0, 0, time.UTC),
+		expected: "13th 13",
+	},
+	{
+		format:   "%o %d",
+		t:        time.Date(2020, time.July, 21, 0, 0, 0, 0, time.UTC),
+		expected: "21st 21",
+	},
+	{
+		format:   "%o %d",
+		t:        time.Date(2020, time.July, 22, 0, 0, 0, 0, time.UTC),
+		expected: "22nd 22",
+	},
+	{
+		format:   "%o %d",
+		t:        time.Date(2020, time.July, 23, 0, 0, 0, 0, time.UTC),
+		expected: "23rd 23",
+	},
+	{
+		format:   "%o %d",
+		t:        time.Date(2020, time.July, 31, 0, 0, 0, 0, time.UTC),
+		expected: "31st 31",
+	},
+	{
+		format:   "%Y-Q%q %Y-H%Q %-q %2q %_2Q",
+		t:        time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+		expected: "2020-Q3 2020-H2 3 03  2",
+	},
+	{
+		format:   "%q%Q",
+		t:        time.Date(2020, time.March, 31, 0, 0, 0, 0, time.UTC),
+		expected: "11",
+	},
+	{
+		format:   "%q%Q",
+		t:        time.Date(2020, time.June, 30, 0, 0, 0, 0, time.UTC),
+		expected: "21",
+	},
+	{
+		format:   "%q%Q",
+		t:        time.Date(2020, time.December, 31, 0, 0, 0, 0, time.UTC),
+		expected: "42",
+	},
 	{
 		format:   "%B %_B %^B %#B %12B %^12B %012B %0^12B",
 		t:        time.Date(2020, time.October, 1, 0, 0, 0, 0, time.UTC),
@@ -0,0 +1,86 @@
+package timefmt
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// Formatted is a time with a format, which implements [fmt.Formatter].
+type Formatted struct {
+	t      time.Time
+	format string
+}
+
+// Fmt returns the time with the format for the functions of fmt package.
+// The verbs %v and %s print the formatted time, and %q prints it quoted.
+// The width and the minus flag are respected for padding, and the precision
+// truncates the formatted time, counting the runes as the strings.
+//
+//	fmt.Printf("[%-12v]\n", timefmt.Fmt(t, "%Y-%m-%d"))
+func Fmt(t time.Time, format string) Formatted {
+	return Formatted{t, format}
+}
+
+// Format implements [fmt.Formatter].
+func (f Formatted) Format(s fmt.State, verb rune) {
+	buf := AppendFormat(make([]byte, 0, 64), f.t, f.format)
+	switch verb {
+	case 'v', 's', 'q':
+	default:
+		fmt.Fprintf(s, "%%!%c(timefmt.Formatted=%s)", verb, buf)
+		return
+	}
+	if precision, ok := s.Precision(); ok {
+		// truncates the runes as the strings of fmt package
+		for i := range string(buf) {
+			if precision--; precision < 0 {
+				buf = buf[:i]
+				break
+			}
+		}
+	}
+	if verb == 'q' {
+		buf = strconv.AppendQuote(make([]byte, 0, len(buf)+2), string(buf))
+	}
+	var padding int
+	if width, ok := s.Width(); ok {
+		padding = width - utf8.RuneCount(buf)
+	}
+	if !s.Flag('-') {
+		writePadding(s, padding)
+	}
+	s.Write(buf)
+	if s.Flag('-') {
+		writePadding(s, padding)
+	}
+}
+
+var spaces = []byte("                                ")
+
+func writePadding(s fmt.State, n int) {
+	for ; n > 0; n -= len(spaces) {
+		s.Write(spaces[:min(n, len(spaces))])
+	}
+}
+
+// String returns the formatted time.
+func (f Formatted) String() string {
+	return Format(f.t, f.format)
+}
+
+// FuncMap returns the functions for text/template and html/template.
+//
+//   - strftime: formats the time using the format (strftime format time)
+//   - strptime: parses the string using the format (strptime format string)
+func FuncMap() map[string]any {
+	return map[string]any{
+		"strftime": func(format string, t time.Time) string {
+			return Format(t, format)
+		},
+		"strptime": func(format, source string) (time.Time, error) {
+			return Parse(source, format)
+		},
+	}
+}
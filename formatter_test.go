@@ -0,0 +1,108 @@
+package timefmt_test
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+)
+
+var fmtTestCases = []struct {
+	format   string
+	expected string
+}{
+	{"%v", "2020-07-24"},
+	{"%s", "2020-07-24"},
+	{"%q", `"2020-07-24"`},
+	{"[%12v]", "[  2020-07-24]"},
+	{"[%-12s]", "[2020-07-24  ]"},
+	{"[%5v]", "[2020-07-24]"},
+	{"[%-50v]", "[2020-07-24" + strings.Repeat(" ", 40) + "]"},
+	{"%14q", `  "2020-07-24"`},
+	{"%.4v", "2020"},
+	{"[%-6.4s]", "[2020  ]"},
+	{"%.7q", `"2020-07"`},
+	{"%.20v", "2020-07-24"},
+	{"%d", "%!d(timefmt.Formatted=2020-07-24)"},
+}
+
+func TestFmt(t *testing.T) {
+	f := timefmt.Fmt(time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC), "%Y-%m-%d")
+	for _, tc := range fmtTestCases {
+		t.Run(tc.format, func(t *testing.T) {
+			if got := fmt.Sprintf(tc.format, f); got != tc.expected {
+				t.Errorf("expected: %q, got: %q", tc.expected, got)
+			}
+		})
+	}
+	if got, expected := f.String(), "2020-07-24"; got != expected {
+		t.Errorf("expected: %q, got: %q", expected, got)
+	}
+	f = timefmt.Fmt(time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC), "%Y年%m月")
+	if got, expected := fmt.Sprintf("[%-12v]", f), "[2020年07月    ]"; got != expected {
+		t.Errorf("expected: %q, got: %q", expected, got)
+	}
+	if got, expected := fmt.Sprintf("[%6.5v]", f), "[ 2020年]"; got != expected {
+		t.Errorf("expected: %q, got: %q", expected, got)
+	}
+}
+
+func TestFuncMap(t *testing.T) {
+	tmpl := template.Must(template.New("").Funcs(timefmt.FuncMap()).Parse(
+		`{{ .Date | strftime "%b %e, %Y" }} {{ (strptime "%Y/%m/%d" .Source).Weekday }}`,
+	))
+	var sb strings.Builder
+	err := tmpl.Execute(&sb, map[string]any{
+		"Date":   time.Date(2020, time.July, 4, 0, 0, 0, 0, time.UTC),
+		"Source": "2020/07/24",
+	})
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if got, expected := sb.String(), "Jul  4, 2020 Friday"; got != expected {
+		t.Errorf("expected: %q, got: %q", expected, got)
+	}
+
+	err = tmpl.Execute(&sb, map[string]any{"Date": time.Time{}, "Source": "2020-07-24"})
+	if expected := `failed to parse "2020-07-24" with "%Y/%m/%d"`; err == nil ||
+		!strings.Contains(err.Error(), expected) {
+		t.Errorf("expected error %q but got: %v", expected, err)
+	}
+
+	htmlTmpl := htmltemplate.Must(htmltemplate.New("").Funcs(timefmt.FuncMap()).Parse(
+		`<time>{{ strftime "%a <%d>" .Date }}</time>`,
+	))
+	sb.Reset()
+	if err := htmlTmpl.Execute(&sb, map[string]any{"Date": time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC)}); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if got, expected := sb.String(), "<time>Fri &lt;24&gt;</time>"; got != expected {
+		t.Errorf("expected: %q, got: %q", expected, got)
+	}
+}
+
+func ExampleFmt() {
+	t := time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC)
+	fmt.Printf("[%-12v] [%12v]\n", timefmt.Fmt(t, "%Y-%m-%d"), timefmt.Fmt(t, "%H:%M:%S"))
+	// Output:
+	// [2020-07-24  ] [    09:07:29]
+}
+
+func ExampleFuncMap() {
+	tmpl := template.Must(template.New("").Funcs(timefmt.FuncMap()).Parse(
+		`Released on {{ .Date | strftime "%A, %B %d, %Y" }}.` + "\n",
+	))
+	if err := tmpl.Execute(os.Stdout, map[string]any{
+		"Date": time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+	}); err != nil {
+		log.Fatal(err)
+	}
+	// Output:
+	// Released on Friday, July 24, 2020.
+}
@@ -0,0 +1,332 @@
+package timefmt
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Period represents an ISO 8601 duration like P1Y2M3DT4H5M6S and P2W.
+// The years, months, weeks and days are calendar-aware on adding to a time.
+type Period struct {
+	Negative    bool
+	Years       int
+	Months      int
+	Weeks       int
+	Days        int
+	Hours       int
+	Minutes     int
+	Seconds     int
+	Nanoseconds int
+}
+
+// ParsePeriod parses an ISO 8601 duration. A decimal fraction is allowed on
+// the lowest time component, and it is carried into the lower components.
+func ParsePeriod(source string) (p Period, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("failed to parse %q as ISO 8601 duration: %w", source, err)
+		}
+	}()
+	s := source
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		p.Negative, s = s[0] == '-', s[1:]
+	}
+	if s == "" || s[0] != 'P' {
+		err = errors.New(`expected "P"`)
+		return
+	}
+	var inTime, fraction bool
+	var units string
+	for s = s[1:]; s != ""; {
+		if s[0] == 'T' {
+			if inTime {
+				err = errors.New(`unexpected "T"`)
+				return
+			}
+			inTime, units, s = true, "", s[1:]
+			if s == "" {
+				err = errors.New(`expected time components after "T"`)
+				return
+			}
+			continue
+		}
+		if fraction {
+			err = errors.New("fraction is allowed only on the lowest component")
+			return
+		}
+		var i int
+		for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+			i++
+		}
+		if i == 0 || i > 9 {
+			err = fmt.Errorf("invalid number %q", s)
+			return
+		}
+		value, _ := strconv.Atoi(s[:i])
+		var frac string
+		if i < len(s) && (s[i] == '.' || s[i] == ',') {
+			j := i + 1
+			for j < len(s) && '0' <= s[j] && s[j] <= '9' {
+				j++
+			}
+			if frac, i, fraction = s[i+1:j], j, true; frac == "" || !inTime {
+				err = errors.New("fraction is allowed only on time components")
+				return
+			}
+		}
+		if i == len(s) {
+			err = fmt.Errorf("expected designator after %q", s[:i])
+			return
+		}
+		designator := s[i]
+		if k := strings.IndexByte("YMWD", designator); inTime || k < 0 {
+			if k = strings.IndexByte("HMS", designator); !inTime || k < 0 {
+				err = fmt.Errorf("unexpected designator %q", designator)
+				return
+			}
+		}
+		if strings.IndexByte(units, designator) >= 0 {
+			err = fmt.Errorf("duplicate designator %q", designator)
+			return
+		}
+		units += string(designator)
+		switch {
+		case !inTime && designator == 'Y':
+			p.Years = value
+		case !inTime && designator == 'M':
+			p.Months = value
+		case designator == 'W':
+			p.Weeks = value
+		case designator == 'D':
+			p.Days = value
+		case designator == 'H':
+			p.Hours = value
+			p.addFraction(frac, time.Hour)
+		case designator == 'M':
+			p.Minutes = value
+			p.addFraction(frac, time.Minute)
+		default:
+			p.Seconds = value
+			p.addFraction(frac, time.Second)
+		}
+		s = s[i+1:]
+	}
+	if units == "" {
+		err = errors.New("expected components")
+	}
+	return
+}
+
+func (p *Period) addFraction(frac string, unit time.Duration) {
+	d := decimalFraction(frac, unit)
+	if unit == time.Hour {
+		p.Minutes += int(d / time.Minute)
+		d %= time.Minute
+	}
+	p.Seconds += int(d / time.Second)
+	p.Nanoseconds += int(d % time.Second)
+}
+
+// String returns the ISO 8601 representation of the duration.
+func (p Period) String() string {
+	buf := make([]byte, 0, 32)
+	if p.Negative {
+		buf = append(buf, '-')
+	}
+	buf = append(buf, 'P')
+	for _, c := range []struct {
+		value      int
+		designator byte
+	}{{p.Years, 'Y'}, {p.Months, 'M'}, {p.Weeks, 'W'}, {p.Days, 'D'}} {
+		if c.value != 0 {
+			buf = append(strconv.AppendInt(buf, int64(c.value), 10), c.designator)
+		}
+	}
+	if p.Hours != 0 || p.Minutes != 0 || p.Seconds != 0 || p.Nanoseconds != 0 || len(buf) == 1 {
+		buf = append(buf, 'T')
+		if p.Hours != 0 {
+			buf = append(strconv.AppendInt(buf, int64(p.Hours), 10), 'H')
+		}
+		if p.Minutes != 0 {
+			buf = append(strconv.AppendInt(buf, int64(p.Minutes), 10), 'M')
+		}
+		if p.Seconds != 0 || p.Nanoseconds != 0 || buf[len(buf)-1] == 'T' {
+			buf = strconv.AppendInt(buf, int64(p.Seconds), 10)
+			if p.Nanoseconds != 0 {
+				buf = append(buf, '.')
+				buf = appendInt(buf, p.Nanoseconds, 9, '0')
+				for buf[len(buf)-1] == '0' {
+					buf = buf[:len(buf)-1]
+				}
+			}
+			buf = append(buf, 'S')
+		}
+	}
+	return string(buf)
+}
+
+// AddTo returns the time added by the duration. The years, months, weeks and
+// days are added by [time.Time.AddDate], and the others are added as the
+// elapsed time.
+func (p Period) AddTo(t time.Time) time.Time {
+	sign := 1
+	if p.Negative {
+		sign = -1
+	}
+	t = t.AddDate(sign*p.Years, sign*p.Months, sign*(p.Weeks*7+p.Days))
+	return t.Add(time.Duration(sign) * (time.Duration(p.Hours)*time.Hour +
+		time.Duration(p.Minutes)*time.Minute +
+		time.Duration(p.Seconds)*time.Second +
+		time.Duration(p.Nanoseconds)))
+}
+
+// Interval represents an ISO 8601 time interval. The interval is represented
+// by start and end, start and duration, duration and end, or only duration.
+// The zero values of the fields represent that they are omitted.
+type Interval struct {
+	Start  time.Time
+	End    time.Time
+	Period Period
+	// Recurrences is the number of repetitions of the interval, which is
+	// -1 for unbounded repetitions and 0 for non-recurring interval.
+	Recurrences int
+}
+
+// ParseInterval parses an ISO 8601 time interval like 2020-07-24T09:00:00Z/PT1H
+// and a recurring interval like R5/2020-07-24/P1D.
+func ParseInterval(source string) (iv Interval, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("failed to parse %q as ISO 8601 interval: %w", source, err)
+		}
+	}()
+	s := source
+	if strings.HasPrefix(s, "R") {
+		i := strings.IndexByte(s, '/')
+		if i < 0 {
+			err = errors.New(`expected "/"`)
+			return
+		}
+		if i == 1 {
+			iv.Recurrences = -1
+		} else if iv.Recurrences, err = strconv.Atoi(s[1:i]); err != nil || iv.Recurrences < 0 {
+			err = fmt.Errorf("invalid recurrences %q", s[1:i])
+			return
+		}
+		s = s[i+1:]
+	}
+	first, second, found := strings.Cut(s, "/")
+	switch {
+	case !found:
+		iv.Period, err = ParsePeriod(first)
+	case isPeriod(first):
+		if isPeriod(second) {
+			err = errors.New("expected time for start or end")
+		} else if iv.Period, err = ParsePeriod(first); err == nil {
+			iv.End, err = parseISODateTime(second)
+		}
+	default:
+		if iv.Start, err = parseISODateTime(first); err != nil {
+			break
+		}
+		if isPeriod(second) {
+			iv.Period, err = ParsePeriod(second)
+		} else {
+			iv.End, err = parseISODateTime(second)
+		}
+	}
+	return
+}
+
+func isPeriod(s string) bool {
+	return strings.HasPrefix(s, "P") || strings.HasPrefix(s, "-P") || strings.HasPrefix(s, "+P")
+}
+
+func parseISODateTime(s string) (time.Time, error) {
+	t, _, err := ParseISO8601(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date time %q", s)
+	}
+	return t, nil
+}
+
+// StartTime returns the start time of the interval, which is calculated from
+// the end and the duration if the start is omitted.
+func (iv Interval) StartTime() time.Time {
+	if iv.Start.IsZero() && !iv.End.IsZero() {
+		p := iv.Period
+		p.Negative = !p.Negative
+		return p.AddTo(iv.End)
+	}
+	return iv.Start
+}
+
+// EndTime returns the end time of the interval, which is calculated from
+// the start and the duration if the end is omitted.
+func (iv Interval) EndTime() time.Time {
+	if iv.End.IsZero() && !iv.Start.IsZero() {
+		return iv.Period.AddTo(iv.Start)
+	}
+	return iv.End
+}
+
+// Recurrence returns the n-th (0-based) interval of the recurring interval.
+func (iv Interval) Recurrence(n int) (start, end time.Time) {
+	start, end = iv.StartTime(), iv.EndTime()
+	if iv.Start.IsZero() || iv.End.IsZero() {
+		for range n {
+			start, end = end, iv.Period.AddTo(end)
+		}
+	} else {
+		d := end.Sub(start)
+		start = start.Add(time.Duration(n) * d)
+		end = start.Add(d)
+	}
+	return
+}
+
+// String returns the ISO 8601 representation of the interval.
+func (iv Interval) String() string {
+	return iv.Format("%Y-%m-%dT%H:%M:%S%:z")
+}
+
+// Format returns the ISO 8601 representation of the interval, formatting
+// the start and end using the format. The UTC offset is formatted as Z.
+func (iv Interval) Format(format string) string {
+	buf := make([]byte, 0, 64)
+	if iv.Recurrences != 0 {
+		buf = append(buf, 'R')
+		if iv.Recurrences > 0 {
+			buf = strconv.AppendInt(buf, int64(iv.Recurrences), 10)
+		}
+		buf = append(buf, '/')
+	}
+	if !iv.Start.IsZero() {
+		buf = appendISOTime(buf, iv.Start, format)
+	}
+	if iv.Start.IsZero() || iv.End.IsZero() {
+		if len(buf) > 0 && buf[len(buf)-1] != '/' {
+			buf = append(buf, '/')
+		}
+		buf = append(buf, iv.Period.String()...)
+	}
+	if !iv.End.IsZero() {
+		buf = append(buf, '/')
+		buf = appendISOTime(buf, iv.End, format)
+	}
+	return string(buf)
+}
+
+func appendISOTime(buf []byte, t time.Time, format string) []byte {
+	i := len(buf)
+	buf = AppendFormat(buf, t, format)
+	if _, offset := t.Zone(); offset == 0 && strings.HasSuffix(format, "%:z") {
+		if j := len(buf) - len("+00:00"); j >= i && string(buf[j:]) == "+00:00" {
+			buf = append(buf[:j], 'Z')
+		}
+	}
+	return buf
+}
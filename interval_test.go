@@ -0,0 +1,258 @@
+package timefmt_test
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+)
+
+var parsePeriodTestCases = []struct {
+	source   string
+	p        timefmt.Period
+	expected string
+	parseErr error
+}{
+	{
+		source:   "P1Y2M3DT4H5M6S",
+		p:        timefmt.Period{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6},
+		expected: "P1Y2M3DT4H5M6S",
+	},
+	{
+		source:   "P2W",
+		p:        timefmt.Period{Weeks: 2},
+		expected: "P2W",
+	},
+	{
+		source:   "PT1H",
+		p:        timefmt.Period{Hours: 1},
+		expected: "PT1H",
+	},
+	{
+		source:   "-P1D",
+		p:        timefmt.Period{Negative: true, Days: 1},
+		expected: "-P1D",
+	},
+	{
+		source:   "PT0.5S",
+		p:        timefmt.Period{Nanoseconds: 500000000},
+		expected: "PT0.5S",
+	},
+	{
+		source:   "PT1,25H",
+		p:        timefmt.Period{Hours: 1, Minutes: 15},
+		expected: "PT1H15M",
+	},
+	{
+		source:   "PT1.5M",
+		p:        timefmt.Period{Minutes: 1, Seconds: 30},
+		expected: "PT1M30S",
+	},
+	{
+		source:   "P0D",
+		p:        timefmt.Period{},
+		expected: "PT0S",
+	},
+	{
+		source:   "1D",
+		parseErr: errors.New(`expected "P"`),
+	},
+	{
+		source:   "P",
+		parseErr: errors.New("expected components"),
+	},
+	{
+		source:   "P1DT",
+		parseErr: errors.New(`expected time components after "T"`),
+	},
+	{
+		source:   "P1H",
+		parseErr: errors.New(`unexpected designator 'H'`),
+	},
+	{
+		source:   "PT1D",
+		parseErr: errors.New(`unexpected designator 'D'`),
+	},
+	{
+		source:   "P1D1D",
+		parseErr: errors.New(`duplicate designator 'D'`),
+	},
+	{
+		source:   "P1.5D",
+		parseErr: errors.New("fraction is allowed only on time components"),
+	},
+	{
+		source:   "PT1.5H1M",
+		parseErr: errors.New("fraction is allowed only on the lowest component"),
+	},
+	{
+		source:   "P1",
+		parseErr: errors.New(`expected designator after "1"`),
+	},
+}
+
+func TestParsePeriod(t *testing.T) {
+	for _, tc := range parsePeriodTestCases {
+		t.Run(tc.source, func(t *testing.T) {
+			got, err := timefmt.ParsePeriod(tc.source)
+			if tc.parseErr == nil {
+				if err != nil {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				if got != tc.p {
+					t.Errorf("expected: %+v, got: %+v", tc.p, got)
+				}
+				if got.String() != tc.expected {
+					t.Errorf("expected: %s, got: %s", tc.expected, got.String())
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("expected error %v but got: %v", tc.parseErr, err)
+				}
+				if !strings.Contains(err.Error(), tc.parseErr.Error()) {
+					t.Errorf("expected: %v, got: %v", tc.parseErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestPeriodAddTo(t *testing.T) {
+	tm := time.Date(2020, time.January, 31, 9, 0, 0, 0, time.UTC)
+	p := timefmt.Period{Months: 1, Hours: 1, Minutes: 30}
+	if got, expected := p.AddTo(tm), time.Date(2020, time.March, 2, 10, 30, 0, 0, time.UTC); !got.Equal(expected) {
+		t.Errorf("expected: %v, got: %v", expected, got)
+	}
+	p.Negative = true
+	if got, expected := p.AddTo(tm), time.Date(2019, time.December, 31, 7, 30, 0, 0, time.UTC); !got.Equal(expected) {
+		t.Errorf("expected: %v, got: %v", expected, got)
+	}
+}
+
+var parseIntervalTestCases = []struct {
+	source   string
+	start    time.Time
+	end      time.Time
+	repeat   int
+	expected string
+	parseErr error
+}{
+	{
+		source:   "2020-07-24T09:00:00Z/PT1H",
+		start:    time.Date(2020, time.July, 24, 9, 0, 0, 0, time.UTC),
+		end:      time.Date(2020, time.July, 24, 10, 0, 0, 0, time.UTC),
+		expected: "2020-07-24T09:00:00Z/PT1H",
+	},
+	{
+		source:   "2020-07-24T09:00:00+09:00/2020-07-25T09:00:00+09:00",
+		start:    time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+		end:      time.Date(2020, time.July, 25, 0, 0, 0, 0, time.UTC),
+		expected: "2020-07-24T09:00:00+09:00/2020-07-25T09:00:00+09:00",
+	},
+	{
+		source:   "P1M/2020-03-31",
+		start:    time.Date(2020, time.March, 2, 0, 0, 0, 0, time.UTC),
+		end:      time.Date(2020, time.March, 31, 0, 0, 0, 0, time.UTC),
+		expected: "P1M/2020-03-31T00:00:00Z",
+	},
+	{
+		source:   "R5/2020-07-24/P1D",
+		start:    time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+		end:      time.Date(2020, time.July, 25, 0, 0, 0, 0, time.UTC),
+		repeat:   5,
+		expected: "R5/2020-07-24T00:00:00Z/P1D",
+	},
+	{
+		source:   "R/20200724T090000Z/PT1H",
+		start:    time.Date(2020, time.July, 24, 9, 0, 0, 0, time.UTC),
+		end:      time.Date(2020, time.July, 24, 10, 0, 0, 0, time.UTC),
+		repeat:   -1,
+		expected: "R/2020-07-24T09:00:00Z/PT1H",
+	},
+	{
+		source:   "PT36H",
+		expected: "PT36H",
+	},
+	{
+		source:   "R5",
+		parseErr: errors.New(`expected "/"`),
+	},
+	{
+		source:   "Rx/2020-07-24/P1D",
+		parseErr: errors.New(`invalid recurrences "x"`),
+	},
+	{
+		source:   "P1D/P1D",
+		parseErr: errors.New("expected time for start or end"),
+	},
+	{
+		source:   "2020-07-24/2020-07-32",
+		parseErr: errors.New(`invalid date time "2020-07-32"`),
+	},
+}
+
+func TestParseInterval(t *testing.T) {
+	for _, tc := range parseIntervalTestCases {
+		t.Run(tc.source, func(t *testing.T) {
+			got, err := timefmt.ParseInterval(tc.source)
+			if tc.parseErr == nil {
+				if err != nil {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				if start := got.StartTime(); !start.Equal(tc.start) {
+					t.Errorf("expected start: %v, got: %v", tc.start, start)
+				}
+				if end := got.EndTime(); !end.Equal(tc.end) {
+					t.Errorf("expected end: %v, got: %v", tc.end, end)
+				}
+				if got.Recurrences != tc.repeat {
+					t.Errorf("expected recurrences: %d, got: %d", tc.repeat, got.Recurrences)
+				}
+				if got.String() != tc.expected {
+					t.Errorf("expected: %s, got: %s", tc.expected, got.String())
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("expected error %v but got: %v", tc.parseErr, err)
+				}
+				if !strings.Contains(err.Error(), tc.parseErr.Error()) {
+					t.Errorf("expected: %v, got: %v", tc.parseErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestIntervalRecurrence(t *testing.T) {
+	iv, err := timefmt.ParseInterval("R3/2020-01-31/P1M")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for i := range iv.Recurrences {
+		start, end := iv.Recurrence(i)
+		got = append(got, timefmt.Format(start, "%F")+"/"+timefmt.Format(end, "%F"))
+	}
+	expected := "2020-01-31/2020-03-02 2020-03-02/2020-04-02 2020-04-02/2020-05-02"
+	if strings.Join(got, " ") != expected {
+		t.Error(diff(expected, strings.Join(got, " ")))
+	}
+}
+
+func ExampleParseInterval() {
+	iv, err := timefmt.ParseInterval("2020-07-24T09:00:00Z/PT1H30M")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(iv.StartTime())
+	fmt.Println(iv.EndTime())
+	fmt.Println(iv.Format("%Y-%m-%dT%H:%M%:z"))
+	// Output:
+	// 2020-07-24 09:00:00 +0000 UTC
+	// 2020-07-24 10:30:00 +0000 UTC
+	// 2020-07-24T09:00Z/PT1H30M
+}
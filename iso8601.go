@@ -0,0 +1,198 @@
+package timefmt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Unit represents a unit of time, which is used for the precision of
+// a time string.
+type Unit int
+
+// Units of time.
+const (
+	UnitSecond Unit = iota + 1
+	UnitMinute
+	UnitHour
+	UnitDay
+	UnitWeek
+	UnitMonth
+	UnitYear
+)
+
+var unitNames = [...]string{
+	UnitSecond: "second",
+	UnitMinute: "minute",
+	UnitHour:   "hour",
+	UnitDay:    "day",
+	UnitWeek:   "week",
+	UnitMonth:  "month",
+	UnitYear:   "year",
+}
+
+// String returns the name of the unit.
+func (u Unit) String() string {
+	if 0 < u && int(u) < len(unitNames) {
+		return unitNames[u]
+	}
+	return fmt.Sprintf("Unit(%d)", int(u))
+}
+
+var iso8601DateFormats = map[string]struct {
+	format string
+	unit   Unit
+}{
+	"dddd":       {"%Y", UnitYear},
+	"dddd-dd":    {"%Y-%m", UnitMonth},
+	"dddd-dd-dd": {"%Y-%m-%d", UnitDay},
+	"dddddddd":   {"%Y%m%d", UnitDay},
+	"dddd-Wdd":   {"%G-W%V", UnitWeek},
+	"ddddWdd":    {"%GW%V", UnitWeek},
+	"dddd-Wdd-d": {"%G-W%V-%u", UnitDay},
+	"ddddWddd":   {"%GW%V%u", UnitDay},
+	"dddd-ddd":   {"%Y-%j", UnitDay},
+	"ddddddd":    {"%Y%j", UnitDay},
+}
+
+var iso8601TimeFormats = map[string]struct {
+	format string
+	unit   Unit
+}{
+	"dd":       {"%H", UnitHour},
+	"dd:dd":    {"%H:%M", UnitMinute},
+	"dddd":     {"%H%M", UnitMinute},
+	"dd:dd:dd": {"%H:%M:%S", UnitSecond},
+	"dddddd":   {"%H%M%S", UnitSecond},
+}
+
+// ParseISO8601 parses a date and time string of ISO 8601. It accepts calendar
+// dates (2020-07-24), week dates (2020-W30-5) and ordinal dates (2020-206) in
+// the basic or extended format, reduced precision (2020-07, 2020-W30, 09:30),
+// a decimal fraction of the lowest time component (09:30.5), the end of day
+// (24:00) and time zone offsets (Z, +09, +0900, +09:00). The time without
+// the date (09:30, T0930) is on January 1, 1900 as [Parse]; the basic format
+// requires the leading T to be distinguished from the year. It returns the
+// time and the unit of the lowest component as the precision. The time
+// without time zone offset is parsed in UTC.
+func ParseISO8601(source string) (t time.Time, precision Unit, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("failed to parse %q as ISO 8601: %w", source, err)
+		}
+	}()
+	date, clock, hasTime := strings.Cut(source, "T")
+	if !hasTime && strings.Contains(date, ":") {
+		// the time of the extended format without the date
+		date, clock, hasTime = "", date, true
+	}
+	var format string
+	if date != "" || !hasTime {
+		var sign string
+		if date != "" && (date[0] == '-' || date[0] == '+') {
+			sign, date = date[:1], date[1:]
+			if sign == "+" {
+				sign = ""
+			}
+		}
+		d, ok := iso8601DateFormats[iso8601Shape(date)]
+		if !ok {
+			err = fmt.Errorf("invalid date %q", date)
+			return
+		}
+		format, precision = d.format, d.unit
+		date = sign + date
+		if !hasTime {
+			t, err = parseISO8601(date, format, date)
+			return
+		}
+		if precision != UnitDay {
+			err = errors.New("time requires complete date")
+			return
+		}
+	}
+	var zone string
+	if i := strings.IndexAny(clock, "Z+-"); i >= 0 {
+		clock, zone = clock[:i], clock[i:]
+	}
+	var fraction string
+	if i := strings.IndexAny(clock, ".,"); i >= 0 {
+		clock, fraction = clock[:i], clock[i+1:]
+		if fraction == "" || iso8601Shape(fraction) != strings.Repeat("d", len(fraction)) {
+			err = fmt.Errorf("invalid fraction %q", fraction)
+			return
+		}
+	}
+	c, ok := iso8601TimeFormats[iso8601Shape(clock)]
+	if !ok {
+		err = fmt.Errorf("invalid time %q", clock)
+		return
+	}
+	format, precision = format+"T"+c.format, c.unit
+	var endOfDay bool
+	if strings.HasPrefix(clock, "24") {
+		if strings.Trim(clock[2:], "0:") != "" || strings.Trim(fraction, "0") != "" {
+			err = errors.New("invalid end of day")
+			return
+		}
+		clock, endOfDay = "00"+clock[2:], true
+	}
+	if zone != "" {
+		format += "%z"
+	}
+	if t, err = parseISO8601(date+"T"+clock+zone, format, date); err != nil {
+		return
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	if fraction != "" {
+		unit := time.Second
+		switch precision {
+		case UnitHour:
+			unit = time.Hour
+		case UnitMinute:
+			unit = time.Minute
+		}
+		t = t.Add(decimalFraction(fraction, unit))
+	}
+	return
+}
+
+// parseISO8601 parses the source with the format, and rejects the day of month
+// normalized by the parser, like 2020-02-30.
+func parseISO8601(source, format, date string) (time.Time, error) {
+	t, err := parse(source, format, time.UTC, time.Local, nil, nil)
+	if err != nil {
+		var pe *ParseError
+		if errors.As(err, &pe) {
+			err = pe.Err
+		}
+		return time.Time{}, err
+	}
+	if strings.Contains(format, "%d") && date[len(date)-2:] != fmt.Sprintf("%02d", t.Day()) {
+		return time.Time{}, fmt.Errorf("invalid day of month %q", date[len(date)-2:])
+	}
+	return t, nil
+}
+
+// decimalFraction returns the duration of the decimal fraction of the unit.
+func decimalFraction(fraction string, unit time.Duration) time.Duration {
+	var d time.Duration
+	for i, scale := 0, unit/10; i < len(fraction) && scale > 0; i, scale = i+1, scale/10 {
+		d += time.Duration(fraction[i]&0x0F) * scale
+	}
+	return d
+}
+
+// iso8601Shape returns the string with the digits replaced by "d".
+func iso8601Shape(s string) string {
+	buf := []byte(s)
+	for i, b := range buf {
+		if '0' <= b && b <= '9' {
+			buf[i] = 'd'
+		}
+	}
+	return string(buf)
+}
@@ -0,0 +1,229 @@
+package timefmt_test
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+)
+
+var parseISO8601TestCases = []struct {
+	source    string
+	t         time.Time
+	precision timefmt.Unit
+	parseErr  error
+}{
+	{
+		source:    "2020",
+		t:         time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+		precision: timefmt.UnitYear,
+	},
+	{
+		source:    "2020-07",
+		t:         time.Date(2020, time.July, 1, 0, 0, 0, 0, time.UTC),
+		precision: timefmt.UnitMonth,
+	},
+	{
+		source:    "2020-07-24",
+		t:         time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+		precision: timefmt.UnitDay,
+	},
+	{
+		source:    "20200724",
+		t:         time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+		precision: timefmt.UnitDay,
+	},
+	{
+		source:    "2020-W30",
+		t:         time.Date(2020, time.July, 20, 0, 0, 0, 0, time.UTC),
+		precision: timefmt.UnitWeek,
+	},
+	{
+		source:    "2020W305",
+		t:         time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+		precision: timefmt.UnitDay,
+	},
+	{
+		source:    "2020-W53-7",
+		t:         time.Date(2021, time.January, 3, 0, 0, 0, 0, time.UTC),
+		precision: timefmt.UnitDay,
+	},
+	{
+		source:    "2020-206",
+		t:         time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+		precision: timefmt.UnitDay,
+	},
+	{
+		source:    "2020206T0930",
+		t:         time.Date(2020, time.July, 24, 9, 30, 0, 0, time.UTC),
+		precision: timefmt.UnitMinute,
+	},
+	{
+		source:    "2020-07-24T09",
+		t:         time.Date(2020, time.July, 24, 9, 0, 0, 0, time.UTC),
+		precision: timefmt.UnitHour,
+	},
+	{
+		source:    "2020-07-24T09:30.5",
+		t:         time.Date(2020, time.July, 24, 9, 30, 30, 0, time.UTC),
+		precision: timefmt.UnitMinute,
+	},
+	{
+		source:    "2020-07-24T09,25Z",
+		t:         time.Date(2020, time.July, 24, 9, 15, 0, 0, time.UTC),
+		precision: timefmt.UnitHour,
+	},
+	{
+		source:    "2020-07-24T09:07:29.123456789+09:00",
+		t:         time.Date(2020, time.July, 24, 9, 7, 29, 123456789, time.FixedZone("", 9*60*60)),
+		precision: timefmt.UnitSecond,
+	},
+	{
+		source:    "20200724T090729-0330",
+		t:         time.Date(2020, time.July, 24, 9, 7, 29, 0, time.FixedZone("", -(3*60+30)*60)),
+		precision: timefmt.UnitSecond,
+	},
+	{
+		source:    "2020-07-24T09:07+09",
+		t:         time.Date(2020, time.July, 24, 9, 7, 0, 0, time.FixedZone("", 9*60*60)),
+		precision: timefmt.UnitMinute,
+	},
+	{
+		source:    "2020-07-24T24:00",
+		t:         time.Date(2020, time.July, 25, 0, 0, 0, 0, time.UTC),
+		precision: timefmt.UnitMinute,
+	},
+	{
+		source:    "2020-12-31T24:00:00Z",
+		t:         time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+		precision: timefmt.UnitSecond,
+	},
+	{
+		source:    "09:30",
+		t:         time.Date(1900, time.January, 1, 9, 30, 0, 0, time.UTC),
+		precision: timefmt.UnitMinute,
+	},
+	{
+		source:    "09:30.5",
+		t:         time.Date(1900, time.January, 1, 9, 30, 30, 0, time.UTC),
+		precision: timefmt.UnitMinute,
+	},
+	{
+		source:    "T0930",
+		t:         time.Date(1900, time.January, 1, 9, 30, 0, 0, time.UTC),
+		precision: timefmt.UnitMinute,
+	},
+	{
+		source:    "T09:07:29+09:00",
+		t:         time.Date(1900, time.January, 1, 9, 7, 29, 0, time.FixedZone("", 9*60*60)),
+		precision: timefmt.UnitSecond,
+	},
+	{
+		source:    "T09",
+		t:         time.Date(1900, time.January, 1, 9, 0, 0, 0, time.UTC),
+		precision: timefmt.UnitHour,
+	},
+	{
+		source:    "2020-02-29T24:00",
+		t:         time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC),
+		precision: timefmt.UnitMinute,
+	},
+	{
+		source:    "-0001-06-15",
+		t:         time.Date(-1, time.June, 15, 0, 0, 0, 0, time.UTC),
+		precision: timefmt.UnitDay,
+	},
+	{
+		source:   "2020-07-24T24:00:01",
+		parseErr: errors.New("invalid end of day"),
+	},
+	{
+		source:   "2020-07T09:00",
+		parseErr: errors.New("time requires complete date"),
+	},
+	{
+		source:   "202007",
+		parseErr: errors.New(`invalid date "202007"`),
+	},
+	{
+		source:   "T",
+		parseErr: errors.New(`invalid time ""`),
+	},
+	{
+		source:   "09:3",
+		parseErr: errors.New(`invalid time "09:3"`),
+	},
+	{
+		source:   "2020-07-24T9:00",
+		parseErr: errors.New(`invalid time "9:00"`),
+	},
+	{
+		source:   "2020-07-24T09:00.",
+		parseErr: errors.New(`invalid fraction ""`),
+	},
+	{
+		source:   "2020-13-24",
+		parseErr: errors.New(`cannot parse "%m"`),
+	},
+	{
+		source:   "2020-02-30",
+		parseErr: errors.New(`invalid day of month "30"`),
+	},
+	{
+		source:   "20210229T12:00",
+		parseErr: errors.New(`invalid day of month "29"`),
+	},
+	{
+		source:   "2020-W54",
+		parseErr: errors.New(`cannot parse "%V"`),
+	},
+}
+
+func TestParseISO8601(t *testing.T) {
+	for _, tc := range parseISO8601TestCases {
+		t.Run(tc.source, func(t *testing.T) {
+			got, precision, err := timefmt.ParseISO8601(tc.source)
+			if tc.parseErr == nil {
+				if err != nil {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				if !got.Equal(tc.t) {
+					t.Errorf("expected: %v, got: %v", tc.t, got)
+				}
+				if precision != tc.precision {
+					t.Errorf("expected precision: %v, got: %v", tc.precision, precision)
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("expected error %v but got: %v", tc.parseErr, err)
+				}
+				if !strings.Contains(err.Error(), tc.parseErr.Error()) {
+					t.Errorf("expected: %v, got: %v", tc.parseErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestParseISO8601Error(t *testing.T) {
+	_, _, err := timefmt.ParseISO8601("2020-13-24T09:00")
+	if expected := `failed to parse "2020-13-24T09:00" as ISO 8601: cannot parse "%m"`; err == nil || err.Error() != expected {
+		t.Errorf("expected error %q but got: %v", expected, err)
+	}
+}
+
+func ExampleParseISO8601() {
+	t, precision, err := timefmt.ParseISO8601("2020-W30-5T09:30")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(t)
+	fmt.Println(precision)
+	// Output:
+	// 2020-07-24 09:30:00 +0000 UTC
+	// minute
+}
@@ -0,0 +1,388 @@
+package timefmt
+
+import (
+	"cmp"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MarshalJSON returns the JSON encoding of the struct, formatting the
+// [time.Time] and *time.Time fields with the timefmt struct tag using the
+// format. The other fields are encoded by [json.Marshal] as usual.
+//
+//	type Event struct {
+//		Date    time.Time  `json:"date" timefmt:"%Y-%m-%d"`
+//		Updated *time.Time `json:"updated" timefmt:"%a, %d %b %Y,loc=Asia/Tokyo"`
+//	}
+//
+// The format is followed by the comma-separated options.
+//
+//   - omitempty: omits the zero time, and parses an empty string as the zero time
+//   - utc: formats in UTC, and parses the string without time zone in UTC
+//   - local: formats in the local time zone, and parses the string without time zone in it
+//   - loc=name: formats in the location, and parses the string without time zone in it
+//
+// The time is formatted in its own location and parsed in UTC by default. The
+// nil pointer is encoded as null. The fields of the embedded structs are
+// flattened as encoding/json does, but the embedded types implementing
+// [json.Marshaler] or [encoding.TextMarshaler], and the embedded pointers to
+// the structs with the timefmt tags are not supported. The string option of
+// the json tag is ignored for the fields with the timefmt tag.
+func MarshalJSON(v any) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return json.Marshal(v)
+	}
+	c, err := structCodecOf(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+	sv := reflect.New(c.marshalType).Elem()
+	for i, f := range c.fields {
+		fv := rv.FieldByIndex(f.index)
+		if f.format == "" {
+			sv.Field(i).Set(fv)
+			continue
+		}
+		if f.pointer {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		t := fv.Interface().(time.Time)
+		if f.omitEmpty && t.IsZero() {
+			continue
+		}
+		if f.loc != nil {
+			t = t.In(f.loc)
+		}
+		s := Format(t, f.format)
+		if f.pointer {
+			sv.Field(i).Set(reflect.ValueOf(&s))
+		} else {
+			sv.Field(i).SetString(s)
+		}
+	}
+	return json.Marshal(sv.Interface())
+}
+
+// UnmarshalJSON parses the JSON-encoded data and stores the result in the
+// struct pointed to by v, parsing the [time.Time] and *time.Time fields with
+// the timefmt struct tag using the format. See [MarshalJSON] for the options.
+// The field is left unchanged if the key is missing, and null sets the pointer
+// field to nil.
+func UnmarshalJSON(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.New("timefmt: UnmarshalJSON requires non-nil pointer")
+	}
+	if rv = rv.Elem(); rv.Kind() != reflect.Struct {
+		return json.Unmarshal(data, v)
+	}
+	c, err := structCodecOf(rv.Type())
+	if err != nil {
+		return err
+	}
+	sv := reflect.New(c.unmarshalType).Elem()
+	for i, f := range c.fields {
+		if f.format == "" {
+			sv.Field(i).Set(rv.FieldByIndex(f.index))
+		}
+	}
+	if err := json.Unmarshal(data, sv.Addr().Interface()); err != nil {
+		return err
+	}
+	for i, f := range c.fields {
+		fv := rv.FieldByIndex(f.index)
+		if f.format == "" {
+			fv.Set(sv.Field(i))
+			continue
+		}
+		raw := sv.Field(i).Interface().(json.RawMessage)
+		if raw == nil {
+			continue
+		}
+		if string(raw) == "null" {
+			if f.pointer {
+				fv.SetZero()
+			}
+			continue
+		}
+		var source string
+		if err := json.Unmarshal(raw, &source); err != nil {
+			return fmt.Errorf("failed to unmarshal %s.%s: %w", rv.Type().Name(), f.name, err)
+		}
+		var t time.Time
+		if source != "" || !f.omitEmpty {
+			loc := f.loc
+			if loc == nil {
+				loc = time.UTC
+			}
+			if t, err = ParseInLocation(source, f.format, loc); err != nil {
+				return fmt.Errorf("failed to unmarshal %s.%s: %w", rv.Type().Name(), f.name, err)
+			}
+		}
+		if f.pointer {
+			fv.Set(reflect.ValueOf(&t))
+		} else {
+			fv.Set(reflect.ValueOf(t))
+		}
+	}
+	return nil
+}
+
+type structCodec struct {
+	marshalType   reflect.Type
+	unmarshalType reflect.Type
+	fields        []structCodecField
+}
+
+type structCodecField struct {
+	name      string
+	index     []int
+	format    string
+	pointer   bool
+	omitEmpty bool
+	loc       *time.Location
+}
+
+var structCodecs sync.Map // map[reflect.Type]*structCodec
+
+var timeType = reflect.TypeFor[time.Time]()
+
+var (
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+func structCodecOf(typ reflect.Type) (*structCodec, error) {
+	if c, ok := structCodecs.Load(typ); ok {
+		return c.(*structCodec), nil
+	}
+	var fields []structCodecShadowField
+	if err := collectStructFields(typ, nil, &fields); err != nil {
+		return nil, err
+	}
+	fields = dominantStructFields(fields)
+	c := &structCodec{}
+	var marshalFields, unmarshalFields []reflect.StructField
+	for i, field := range fields {
+		c.fields = append(c.fields, field.structCodecField)
+		sf := reflect.StructField{
+			Name: "F" + strconv.Itoa(i),
+			Type: field.typ,
+			Tag:  reflect.StructTag(`json:` + strconv.Quote(field.jsonTag)),
+		}
+		if field.anonymous {
+			sf.Name, sf.Tag, sf.Anonymous = field.name, "", true
+		}
+		if field.format == "" {
+			marshalFields, unmarshalFields = append(marshalFields, sf), append(unmarshalFields, sf)
+			continue
+		}
+		if sf.Type = reflect.TypeFor[string](); field.pointer {
+			sf.Type = reflect.TypeFor[*string]()
+		}
+		marshalFields = append(marshalFields, sf)
+		sf.Type = reflect.TypeFor[json.RawMessage]()
+		unmarshalFields = append(unmarshalFields, sf)
+	}
+	c.marshalType = reflect.StructOf(marshalFields)
+	c.unmarshalType = reflect.StructOf(unmarshalFields)
+	v, _ := structCodecs.LoadOrStore(typ, c)
+	return v.(*structCodec), nil
+}
+
+type structCodecShadowField struct {
+	structCodecField
+	typ     reflect.Type
+	key     string // the key in JSON
+	jsonTag string
+	tagged  bool // has the name in the json tag
+
+	anonymous bool
+}
+
+// collectStructFields collects the fields of the struct, flattening the
+// embedded struct fields as encoding/json does, since [reflect.StructOf] does
+// not support the promoted methods of the embedded fields.
+func collectStructFields(typ reflect.Type, index []int, fields *[]structCodecShadowField) error {
+	for i := range typ.NumField() {
+		sf := typ.Field(i)
+		jsonTag := sf.Tag.Get("json")
+		if jsonTag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(jsonTag, ",")
+		tag, tagged := sf.Tag.Lookup("timefmt")
+		if sf.Anonymous && name == "" && !tagged {
+			t := sf.Type
+			if t.Kind() == reflect.Pointer {
+				t = t.Elem()
+			}
+			if !sf.IsExported() && (t.Kind() != reflect.Struct || sf.Type.Kind() == reflect.Pointer) {
+				continue
+			}
+			if t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) ||
+				reflect.PointerTo(t).Implements(jsonMarshalerType) ||
+				reflect.PointerTo(t).Implements(textMarshalerType) {
+				return fmt.Errorf("timefmt: embedded field %s.%s of type %s implements marshaler",
+					typ.Name(), sf.Name, sf.Type)
+			}
+			if sf.Type.Kind() == reflect.Pointer && sf.IsExported() && sf.Type.NumMethod() == 0 {
+				// the embedded pointer is encoded as is since it may be nil
+				if hasTimefmtTag(t, nil) {
+					return fmt.Errorf("timefmt: embedded pointer field %s.%s of type %s with timefmt tag",
+						typ.Name(), sf.Name, sf.Type)
+				}
+				*fields = append(*fields, structCodecShadowField{
+					structCodecField: structCodecField{name: sf.Name, index: append(slices.Clip(index), i)},
+					typ:              sf.Type,
+					key:              sf.Name,
+					anonymous:        true,
+				})
+				continue
+			}
+			if t.Kind() == reflect.Struct {
+				if sf.Type.Kind() == reflect.Pointer {
+					return fmt.Errorf("timefmt: embedded field %s.%s of type %s with methods",
+						typ.Name(), sf.Name, sf.Type)
+				}
+				if err := collectStructFields(t, append(slices.Clip(index), i), fields); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		f := structCodecShadowField{
+			structCodecField: structCodecField{name: sf.Name, index: append(slices.Clip(index), i)},
+			typ:              sf.Type,
+			key:              cmp.Or(name, sf.Name),
+			jsonTag:          jsonTag,
+			tagged:           name != "",
+		}
+		if !tagged {
+			f.jsonTag = f.key
+			if options != "" {
+				f.jsonTag += "," + options
+			}
+			*fields = append(*fields, f)
+			continue
+		}
+		if f.pointer = sf.Type.Kind() == reflect.Pointer; f.pointer && sf.Type.Elem() != timeType ||
+			!f.pointer && sf.Type != timeType {
+			return fmt.Errorf("timefmt: tag on field %s.%s of type %s", typ.Name(), sf.Name, sf.Type)
+		}
+		var err error
+		if f.format, f.omitEmpty, f.loc, err = parseTimefmtTag(tag); err != nil {
+			return fmt.Errorf("timefmt: tag on field %s.%s: %w", typ.Name(), sf.Name, err)
+		}
+		f.jsonTag = f.key
+		for option := range strings.SplitSeq(options, ",") {
+			// the formatted string is not quoted again by the string option
+			if option != "" && option != "string" {
+				f.jsonTag += "," + option
+			}
+		}
+		if f.omitEmpty && !strings.Contains(f.jsonTag, ",omitempty") {
+			f.jsonTag += ",omitempty"
+		}
+		*fields = append(*fields, f)
+	}
+	return nil
+}
+
+// hasTimefmtTag reports whether the struct has a field with the timefmt tag,
+// including the fields of the embedded structs.
+func hasTimefmtTag(typ reflect.Type, visited []reflect.Type) bool {
+	if typ.Kind() != reflect.Struct || slices.Contains(visited, typ) {
+		return false
+	}
+	visited = append(visited, typ)
+	for i := range typ.NumField() {
+		sf := typ.Field(i)
+		if _, ok := sf.Tag.Lookup("timefmt"); ok {
+			return true
+		}
+		if t := sf.Type; sf.Anonymous {
+			if t.Kind() == reflect.Pointer {
+				t = t.Elem()
+			}
+			if hasTimefmtTag(t, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// dominantStructFields resolves the conflicts of the keys as encoding/json
+// does. The shallowest field wins, and the tagged one wins at the same depth.
+// The other conflicting fields are all dropped.
+func dominantStructFields(fields []structCodecShadowField) []structCodecShadowField {
+	var dominants []structCodecShadowField
+	for i, f := range fields {
+		dominant, conflict := true, false
+		for j, g := range fields {
+			if i == j || f.key != g.key {
+				continue
+			}
+			switch {
+			case len(g.index) < len(f.index), len(g.index) == len(f.index) && g.tagged && !f.tagged:
+				dominant = false
+			case len(g.index) == len(f.index) && g.tagged == f.tagged:
+				conflict = true
+			}
+		}
+		if dominant && !conflict {
+			dominants = append(dominants, f)
+		}
+	}
+	return dominants
+}
+
+// parseTimefmtTag parses the options from the end of the tag, since the format
+// may contain commas.
+func parseTimefmtTag(tag string) (format string, omitEmpty bool, loc *time.Location, err error) {
+	format = tag
+	for {
+		i := strings.LastIndexByte(format, ',')
+		if i < 0 {
+			break
+		}
+		switch option := format[i+1:]; {
+		case option == "omitempty":
+			omitEmpty = true
+		case option == "utc":
+			loc = time.UTC
+		case option == "local":
+			loc = time.Local
+		case strings.HasPrefix(option, "loc="):
+			if loc, err = time.LoadLocation(option[4:]); err != nil {
+				return
+			}
+		default:
+			return
+		}
+		format = format[:i]
+	}
+	if format == "" {
+		err = errors.New("empty format")
+	}
+	return
+}
@@ -0,0 +1,308 @@
+package timefmt_test
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+)
+
+type JSONTagBase struct {
+	ID int `json:"id"`
+}
+
+func (JSONTagBase) String() string { return "base" }
+
+type jsonTagEvent struct {
+	JSONTagBase
+	Name     string     `json:"name"`
+	Date     time.Time  `json:"date" timefmt:"%Y-%m-%d"`
+	Start    time.Time  `json:"start" timefmt:"%a, %d %b %Y %H:%M,utc"`
+	Local    time.Time  `json:"local" timefmt:"%Y-%m-%d %H:%M,loc=Asia/Tokyo"`
+	End      time.Time  `json:"end,omitempty" timefmt:"%H:%M,omitempty"`
+	Updated  *time.Time `json:"updated" timefmt:"%Y/%m/%d %H:%M:%S"`
+	Deleted  *time.Time `json:"deleted,omitempty" timefmt:"%s"`
+	Created  time.Time  `json:"created"`
+	Skipped  time.Time  `json:"-" timefmt:"%Y"`
+	internal int
+}
+
+var jsonTagTestCases = []struct {
+	name  string
+	event jsonTagEvent
+	json  string
+}{
+	{
+		name: "all",
+		event: func() jsonTagEvent {
+			updated := time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC)
+			deleted := time.Date(2020, time.July, 25, 0, 0, 0, 0, time.UTC)
+			return jsonTagEvent{
+				JSONTagBase: JSONTagBase{ID: 1},
+				Name:        "release",
+				Date:        time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+				Start:       time.Date(2020, time.July, 24, 18, 0, 0, 0, time.FixedZone("", 9*60*60)),
+				Local:       time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+				End:         time.Date(1900, time.January, 1, 17, 30, 0, 0, time.UTC),
+				Updated:     &updated,
+				Deleted:     &deleted,
+				Created:     time.Date(2020, time.July, 1, 0, 0, 0, 0, time.UTC),
+			}
+		}(),
+		json: `{"id":1,"name":"release","date":"2020-07-24","start":"Fri, 24 Jul 2020 09:00",` +
+			`"local":"2020-07-24 09:00","end":"17:30","updated":"2020/07/24 09:07:29",` +
+			`"deleted":"1595635200","created":"2020-07-01T00:00:00Z"}`,
+	},
+	{
+		name: "empty",
+		json: `{"id":0,"name":"","date":"0001-01-01","start":"Mon, 01 Jan 0001 00:00",` +
+			`"local":"0001-01-01 09:18","updated":null,"created":"0001-01-01T00:00:00Z"}`,
+	},
+}
+
+func TestMarshalJSON(t *testing.T) {
+	for _, tc := range jsonTagTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := timefmt.MarshalJSON(&tc.event)
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+			if string(got) != tc.json {
+				t.Errorf("expected: %s, got: %s", tc.json, string(got))
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tc := jsonTagTestCases[0]
+	var got jsonTagEvent
+	if err := timefmt.UnmarshalJSON([]byte(tc.json), &got); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	expected := tc.event
+	if got.ID != expected.ID || got.Name != expected.Name {
+		t.Errorf("expected: %+v, got: %+v", expected, got)
+	}
+	for _, c := range []struct {
+		name          string
+		expected, got time.Time
+	}{
+		{name: "date", expected: expected.Date, got: got.Date},
+		{name: "start", expected: expected.Start, got: got.Start},
+		{name: "local", expected: expected.Local, got: got.Local},
+		{name: "end", expected: expected.End, got: got.End},
+		{name: "updated", expected: *expected.Updated, got: *got.Updated},
+		{name: "deleted", expected: *expected.Deleted, got: *got.Deleted},
+		{name: "created", expected: expected.Created, got: got.Created},
+	} {
+		if !c.got.Equal(c.expected) {
+			t.Errorf("%s: expected: %v, got: %v", c.name, c.expected, c.got)
+		}
+	}
+	if loc := got.Local.Location().String(); loc != "Asia/Tokyo" {
+		t.Errorf("expected location Asia/Tokyo but got: %s", loc)
+	}
+
+	updated := time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC)
+	got = jsonTagEvent{Name: "keep", Updated: &updated, End: updated}
+	if err := timefmt.UnmarshalJSON([]byte(`{"updated":null,"end":""}`), &got); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if got.Name != "keep" || got.Updated != nil || !got.End.IsZero() {
+		t.Errorf("unexpected result: %+v", got)
+	}
+}
+
+func TestUnmarshalJSONError(t *testing.T) {
+	for _, tc := range []struct {
+		json string
+		err  string
+	}{
+		{`{"date":"2020/07/24"}`, `failed to unmarshal jsonTagEvent.Date: failed to parse "2020/07/24" with "%Y-%m-%d"`},
+		{`{"date":""}`, `failed to unmarshal jsonTagEvent.Date: failed to parse "" with "%Y-%m-%d"`},
+		{`{"date":20200724}`, `failed to unmarshal jsonTagEvent.Date: json: cannot unmarshal number`},
+		{`{"name":1}`, `json: cannot unmarshal number`},
+	} {
+		var got jsonTagEvent
+		if err := timefmt.UnmarshalJSON([]byte(tc.json), &got); err == nil ||
+			!strings.Contains(err.Error(), tc.err) {
+			t.Errorf("%s: expected error %q but got: %v", tc.json, tc.err, err)
+		}
+	}
+	if err := timefmt.UnmarshalJSON([]byte(`{}`), jsonTagEvent{}); err == nil {
+		t.Errorf("expected error but got nil")
+	}
+}
+
+func TestMarshalJSONTagError(t *testing.T) {
+	for _, tc := range []struct {
+		v   any
+		err string
+	}{
+		{
+			struct {
+				Date string `timefmt:"%Y"`
+			}{},
+			"timefmt: tag on field .Date of type string",
+		},
+		{
+			struct {
+				Date time.Time `timefmt:",utc"`
+			}{},
+			"timefmt: tag on field .Date: empty format",
+		},
+		{
+			struct {
+				Date time.Time `timefmt:"%Y,loc=Unknown/Zone"`
+			}{},
+			"timefmt: tag on field .Date: unknown time zone Unknown/Zone",
+		},
+	} {
+		if _, err := timefmt.MarshalJSON(tc.v); err == nil || err.Error() != tc.err {
+			t.Errorf("expected error %q but got: %v", tc.err, err)
+		}
+	}
+}
+
+type jsonTagInner struct {
+	ID   int       `json:"id"`
+	Name string    `json:"name"`
+	Date time.Time `json:"date" timefmt:"%Y-%m-%d"`
+}
+
+type jsonTagPointer struct {
+	Note string `json:"note"`
+}
+
+func TestMarshalJSONEmbedded(t *testing.T) {
+	type event struct {
+		jsonTagInner
+		*jsonTagPointer
+		Name string `json:"name"`
+	}
+	date := time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC)
+	v := event{jsonTagInner{1, "inner", date}, nil, "outer"}
+	got, err := timefmt.MarshalJSON(v)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if expected := `{"id":1,"date":"2020-07-24","name":"outer"}`; string(got) != expected {
+		t.Errorf("expected: %s, got: %s", expected, got)
+	}
+	var w event
+	if err := timefmt.UnmarshalJSON(got, &w); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if expected := (event{jsonTagInner{1, "", date}, nil, "outer"}); !reflect.DeepEqual(w, expected) {
+		t.Errorf("expected: %+v, got: %+v", expected, w)
+	}
+}
+
+func TestMarshalJSONEmbeddedPointer(t *testing.T) {
+	type Pointer struct {
+		Note string `json:"note"`
+	}
+	type event struct {
+		*Pointer
+		Date time.Time `json:"date" timefmt:"%Y-%m-%d"`
+	}
+	v := event{&Pointer{"memo"}, time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC)}
+	got, err := timefmt.MarshalJSON(v)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if expected := `{"note":"memo","date":"2020-07-24"}`; string(got) != expected {
+		t.Errorf("expected: %s, got: %s", expected, got)
+	}
+	var w event
+	if err := timefmt.UnmarshalJSON(got, &w); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if !reflect.DeepEqual(w, v) {
+		t.Errorf("expected: %+v, got: %+v", v, w)
+	}
+}
+
+func TestMarshalJSONEmbeddedPointerTag(t *testing.T) {
+	type Base struct {
+		Created time.Time `json:"created" timefmt:"%Y-%m-%d"`
+	}
+	type event struct {
+		*Base
+	}
+	expected := "timefmt: embedded pointer field event.Base of type *timefmt_test.Base with timefmt tag"
+	if _, err := timefmt.MarshalJSON(event{&Base{}}); err == nil || err.Error() != expected {
+		t.Errorf("expected error %q but got: %v", expected, err)
+	}
+	var v event
+	if err := timefmt.UnmarshalJSON([]byte(`{"created":"2020-07-24"}`), &v); err == nil || err.Error() != expected {
+		t.Errorf("expected error %q but got: %v", expected, err)
+	}
+}
+
+func TestMarshalJSONStringOption(t *testing.T) {
+	type event struct {
+		At time.Time `json:"at,string" timefmt:"%Y"`
+		ID int       `json:"id,string"`
+	}
+	v := event{time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC), 1}
+	got, err := timefmt.MarshalJSON(v)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if expected := `{"at":"2020","id":"1"}`; string(got) != expected {
+		t.Errorf("expected: %s, got: %s", expected, got)
+	}
+	var w event
+	if err := timefmt.UnmarshalJSON(got, &w); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if !reflect.DeepEqual(w, v) {
+		t.Errorf("expected: %+v, got: %+v", v, w)
+	}
+}
+
+func TestMarshalJSONEmbeddedMarshaler(t *testing.T) {
+	v := struct {
+		time.Time
+		Date time.Time `timefmt:"%Y-%m-%d"`
+	}{}
+	expected := "timefmt: embedded field .Time of type time.Time implements marshaler"
+	if _, err := timefmt.MarshalJSON(v); err == nil || err.Error() != expected {
+		t.Errorf("expected error %q but got: %v", expected, err)
+	}
+	if err := timefmt.UnmarshalJSON([]byte(`{}`), &v); err == nil || err.Error() != expected {
+		t.Errorf("expected error %q but got: %v", expected, err)
+	}
+}
+
+func ExampleMarshalJSON() {
+	type Event struct {
+		Name  string    `json:"name"`
+		Date  time.Time `json:"date" timefmt:"%Y-%m-%d"`
+		Start time.Time `json:"start" timefmt:"%d %b %Y %H:%M,utc"`
+		End   time.Time `json:"end,omitempty" timefmt:"%d %b %Y %H:%M,omitempty"`
+	}
+	bs, err := timefmt.MarshalJSON(Event{
+		Name:  "release",
+		Date:  time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+		Start: time.Date(2020, time.July, 24, 18, 0, 0, 0, time.FixedZone("", 9*60*60)),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(string(bs))
+	var e Event
+	if err := timefmt.UnmarshalJSON(bs, &e); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(e.Start)
+	// Output:
+	// {"name":"release","date":"2020-07-24","start":"24 Jul 2020 09:00"}
+	// 2020-07-24 09:00:00 +0000 UTC
+}
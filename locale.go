@@ -0,0 +1,61 @@
+package timefmt
+
+import "time"
+
+// Locale represents the language dependent representations of the directives.
+// The zero value and a nil Locale represent English.
+type Locale struct {
+	// OrdinalSuffix returns the suffix of the day of month for the ordinal
+	// directive (%o), defaults to [OrdinalSuffix].
+	OrdinalSuffix func(day int) string
+	// DescribeDirective returns the description of the directive token for
+	// [Locale.Describe], defaults to [DescribeDirective].
+	DescribeDirective func(token Token) string
+}
+
+// Format time to string using the format in the locale.
+func (lc *Locale) Format(t time.Time, format string) string {
+	return string(lc.AppendFormat(make([]byte, 0, 64), t, format))
+}
+
+// AppendFormat appends formatted time string in the locale to the buffer.
+func (lc *Locale) AppendFormat(buf []byte, t time.Time, format string) []byte {
+	return appendFormat(buf, t, format, nil, lc)
+}
+
+// Parse time string using the format in the locale.
+func (lc *Locale) Parse(source, format string) (time.Time, error) {
+	return parse(source, format, time.UTC, time.Local, nil, lc)
+}
+
+// ParseInLocation parses time string using the format in the locale with the
+// default location.
+func (lc *Locale) ParseInLocation(source, format string, loc *time.Location) (time.Time, error) {
+	return parse(source, format, loc, loc, nil, lc)
+}
+
+// Regexp returns a regular expression which matches the strings formatted by
+// the format in the locale. See [Regexp] for the details.
+func (lc *Locale) Regexp(format string) (string, error) {
+	return formatRegexp(format, lc)
+}
+
+// Describe returns the description of the format in the locale. See
+// [Describe] for the details.
+func (lc *Locale) Describe(format string) string {
+	return describe(format, lc)
+}
+
+func (lc *Locale) ordinalSuffix(day int) string {
+	if lc == nil || lc.OrdinalSuffix == nil {
+		return OrdinalSuffix(day)
+	}
+	return lc.OrdinalSuffix(day)
+}
+
+func (lc *Locale) describeDirective(token Token) string {
+	if lc == nil || lc.DescribeDirective == nil {
+		return DescribeDirective(token)
+	}
+	return lc.DescribeDirective(token)
+}
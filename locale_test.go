@@ -0,0 +1,77 @@
+package timefmt_test
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+)
+
+var germanLocale = &timefmt.Locale{
+	OrdinalSuffix: func(int) string { return "." },
+}
+
+func TestLocaleFormat(t *testing.T) {
+	tm := time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC)
+	if got, expected := germanLocale.Format(tm, "%o %B %Y"), "24. July 2020"; got != expected {
+		t.Errorf("expected: %q, got: %q", expected, got)
+	}
+	if got, expected := timefmt.Format(tm, "%o %B %Y"), "24th July 2020"; got != expected {
+		t.Errorf("expected: %q, got: %q", expected, got)
+	}
+	var lc *timefmt.Locale
+	if got, expected := lc.Format(tm, "%o"), "24th"; got != expected {
+		t.Errorf("expected: %q, got: %q", expected, got)
+	}
+}
+
+func TestLocaleParse(t *testing.T) {
+	expected := time.Date(2020, time.July, 1, 0, 0, 0, 0, time.UTC)
+	got, err := germanLocale.Parse("1. July 2020", "%o %B %Y")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if !got.Equal(expected) {
+		t.Errorf("expected: %v, got: %v", expected, got)
+	}
+	if _, err := germanLocale.Parse("1st July 2020", "%o %B %Y"); err == nil {
+		t.Errorf("expected error but got nil")
+	}
+	loc := time.FixedZone("", 9*60*60)
+	got, err = germanLocale.ParseInLocation("1. July 2020", "%o %B %Y", loc)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if expected := time.Date(2020, time.July, 1, 0, 0, 0, 0, loc); !got.Equal(expected) {
+		t.Errorf("expected: %v, got: %v", expected, got)
+	}
+}
+
+func TestLocaleRegexp(t *testing.T) {
+	pattern, err := germanLocale.Regexp("%o %B")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	re := regexp.MustCompile("^" + pattern + "$")
+	for source, expected := range map[string]bool{"24. July": true, "1. May": true, "24th July": false} {
+		if got := re.MatchString(source); got != expected {
+			t.Errorf("%q: expected: %v, got: %v", source, expected, got)
+		}
+	}
+}
+
+func ExampleLocale() {
+	lc := &timefmt.Locale{OrdinalSuffix: func(int) string { return "." }}
+	t, err := lc.Parse("24. July 2020", "%o %B %Y")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(t)
+	fmt.Println(lc.Format(t, "%o %b"))
+	// Output:
+	// 2020-07-24 00:00:00 +0000 UTC
+	// 24. Jul
+}
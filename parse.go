@@ -8,340 +8,402 @@ import (
 
 // Parse time string using the format.
 func Parse(source, format string) (t time.Time, err error) {
-	return parse(source, format, time.UTC, time.Local)
+	return parse(source, format, time.UTC, time.Local, nil, nil)
 }
 
 // ParseInLocation parses time string with the default location.
 // The location is also used to parse the time zone name (%Z).
 func ParseInLocation(source, format string, loc *time.Location) (t time.Time, err error) {
-	return parse(source, format, loc, loc)
+	return parse(source, format, loc, loc, nil, nil)
 }
 
-func parse(source, format string, loc, base *time.Location) (t time.Time, err error) {
+// ParseError represents a failure of parsing a time string with a format.
+type ParseError struct {
+	Source string
+	Format string
+	Err    error
+}
+
+// Error returns the error message.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("failed to parse %q with %q: %v", e.Source, e.Format, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+func parse(source, format string, loc, base *time.Location, cal *FiscalCalendar, lc *Locale) (time.Time, error) {
+	if t, ok := parsePreset(source, format, loc); ok {
+		return t, nil
+	}
+	t, _, err := parseTime(source, format, loc, base, cal, lc, false)
+	return t, err
+}
+
+// parsePrefix parses the leading time string of the source, and returns the
+// time and the length of the time string.
+func parsePrefix(source, format string, loc *time.Location) (time.Time, int, error) {
+	return parseTime(source, format, loc, loc, nil, nil, true)
+}
+
+func parseTime(source, format string, loc, base *time.Location, cal *FiscalCalendar,
+	lc *Locale, prefix bool) (t time.Time, n int, err error) {
 	year, month, day, hour, minute, second, nanosecond := 1900, 1, 0, 0, 0, 0, 0
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("failed to parse %q with %q: %w", source, format, err)
+			err = &ParseError{source, format, err}
 		}
 	}()
-	var j, week, weekday, yday, colons, sign int
+	var j, week, weekday, yday, quarter, half, sign int
 	century, weekstart := -1, time.Weekday(-1)
-	var pm, hasISOYear, hasZoneName, hasZoneOffset bool
-	var pending string
-	for i, l := 0, len(source); i < len(format); i++ {
-		if b := format[i]; b == '%' {
-			if i++; i == len(format) {
-				err = errors.New(`stray "%"`)
+	var fiscalYear, fiscalQuarter, fiscalPeriod, fiscalWeek int
+	var pm, hasYear, hasISOYear, hasMonth, hasFiscalYear, hasZoneName, hasZoneOffset, fraction bool
+	l := len(source)
+	tokens := tokenizer{format: format}
+	for tokens.next() {
+		token := &tokens.token
+		if fraction {
+			// accepts nanoseconds unless followed by a directive of digits
+			if nanosecond, j, err = parseFraction(source, j, token.isDigits()); err != nil {
+				return
+			}
+			fraction = false
+		}
+		b := token.Directive
+		if b == 0 {
+			if token.Literal[0] == '%' {
+				if len(token.Literal) == 1 {
+					err = errors.New(`stray "%"`)
+				} else {
+					err = fmt.Errorf(`unexpected format "%%%c"`, token.Literal[1])
+				}
+				return
+			}
+			for i := 0; i < len(token.Literal); i++ {
+				if j >= l || source[j] != token.Literal[i] {
+					err = expectedFormatError(token.Literal[i])
+					return
+				}
+				j++
+			}
+			continue
+		}
+		if token.Padding != 0 || token.Width != 0 || token.Upper || token.Swap {
+			flag := token.Literal[1]
+			if token.Composite != 0 {
+				flag = '^' // the composite directive passes only the ^ flag
+			}
+			err = fmt.Errorf(`unexpected format "%%%c"`, flag)
+			return
+		}
+		if token.Modifier != 0 {
+			if cal == nil {
+				err = fmt.Errorf(`unexpected format "%%%c"`, token.Modifier)
 				return
 			}
-			b = format[i]
-		L:
 			switch b {
-			case 'G':
-				hasISOYear = true
-				fallthrough
 			case 'Y':
 				sign, j = parseSign(source, j, l)
-				if year, j, err = parseInt(source, j, 4, 0, 9999, b); err != nil {
-					return
-				}
-				year *= sign
-			case 'g':
-				hasISOYear = true
-				fallthrough
+				fiscalYear, j, err = parseNumber(source, j, token.Modifier, b)
+				fiscalYear *= sign
 			case 'y':
-				if year, j, err = parseInt(source, j, 2, 0, 99, b); err != nil {
-					return
-				}
-				if year < 69 {
-					year += 2000
+				if fiscalYear, j, err = parseNumber(source, j, token.Modifier, b); fiscalYear < 69 {
+					fiscalYear += 2000
 				} else {
-					year += 1900
-				}
-			case 'C':
-				sign, j = parseSign(source, j, l)
-				if sign < 0 {
-					err = errors.New(`negative century is not supported for "%C"`)
-					return
-				}
-				if century, j, err = parseInt(source, j, 2, 0, 99, 'C'); err != nil {
-					return
+					fiscalYear += 1900
 				}
+			case 'q':
+				fiscalQuarter, j, err = parseNumber(source, j, token.Modifier, b)
 			case 'm':
-				if month, j, err = parseInt(source, j, 2, 1, 12, 'm'); err != nil {
-					return
-				}
-			case 'B':
-				if month, j, err = parseAny(source, j, longMonthNames, 'B'); err != nil {
-					return
-				}
-			case 'b', 'h':
-				if month, j, err = parseAny(source, j, shortMonthNames, b); err != nil {
-					return
-				}
-			case 'A':
-				if weekday, j, err = parseAny(source, j, longWeekNames, 'A'); err != nil {
-					return
-				}
-			case 'a':
-				if weekday, j, err = parseAny(source, j, shortWeekNames, 'a'); err != nil {
-					return
-				}
-			case 'w':
-				if weekday, j, err = parseInt(source, j, 1, 0, 6, 'w'); err != nil {
-					return
-				}
-				weekday++
-			case 'u':
-				if weekday, j, err = parseInt(source, j, 1, 1, 7, 'u'); err != nil {
-					return
-				}
-				weekday = weekday%7 + 1
+				fiscalPeriod, j, err = parseNumber(source, j, token.Modifier, b)
 			case 'V':
-				if week, j, err = parseInt(source, j, 2, 1, 53, b); err != nil {
-					return
-				}
-				weekstart = time.Thursday
-				weekday = or(weekday, 2)
-			case 'U':
-				if week, j, err = parseInt(source, j, 2, 0, 53, b); err != nil {
-					return
-				}
-				weekstart = time.Sunday
-				weekday = or(weekday, 1)
-			case 'W':
-				if week, j, err = parseInt(source, j, 2, 0, 53, b); err != nil {
-					return
-				}
-				weekstart = time.Monday
-				weekday = or(weekday, 2)
-			case 'e':
-				if j < l && source[j] == ' ' {
-					j++
-				}
-				fallthrough
-			case 'd':
-				if day, j, err = parseInt(source, j, 2, 1, 31, b); err != nil {
-					return
-				}
-			case 'j':
-				if yday, j, err = parseInt(source, j, 3, 1, 366, 'j'); err != nil {
-					return
-				}
-			case 'k':
-				if j < l && source[j] == ' ' {
-					j++
-				}
-				fallthrough
-			case 'H':
-				if hour, j, err = parseInt(source, j, 2, 0, 23, b); err != nil {
-					return
-				}
-			case 'l':
-				if j < l && source[j] == ' ' {
-					j++
+				fiscalWeek, j, err = parseNumber(source, j, token.Modifier, b)
+			}
+			if err != nil {
+				err = fmt.Errorf(`cannot parse "%%E%c"`, b)
+				return
+			}
+			hasFiscalYear = hasFiscalYear || b == 'Y' || b == 'y'
+			continue
+		}
+		switch b {
+		case 'G':
+			hasISOYear = true
+			fallthrough
+		case 'Y':
+			hasYear = true
+			sign, j = parseSign(source, j, l)
+			if year, j, err = parseNumber(source, j, token.Modifier, b); err != nil {
+				return
+			}
+			year *= sign
+		case 'g':
+			hasISOYear = true
+			fallthrough
+		case 'y':
+			hasYear = true
+			if year, j, err = parseNumber(source, j, token.Modifier, b); err != nil {
+				return
+			}
+			if year < 69 {
+				year += 2000
+			} else {
+				year += 1900
+			}
+		case 'C':
+			sign, j = parseSign(source, j, l)
+			if sign < 0 {
+				err = errors.New(`negative century is not supported for "%C"`)
+				return
+			}
+			if century, j, err = parseNumber(source, j, token.Modifier, b); err != nil {
+				return
+			}
+			hasYear = true
+		case 'm':
+			if month, j, err = parseNumber(source, j, token.Modifier, b); err != nil {
+				return
+			}
+			hasMonth = true
+		case 'B':
+			if month, j, err = parseAny(source, j, longMonthNames, 'B'); err != nil {
+				return
+			}
+			hasMonth = true
+		case 'b', 'h':
+			if month, j, err = parseAny(source, j, shortMonthNames, b); err != nil {
+				return
+			}
+			hasMonth = true
+		case 'q':
+			if quarter, j, err = parseNumber(source, j, token.Modifier, b); err != nil {
+				return
+			}
+		case 'Q':
+			if half, j, err = parseNumber(source, j, token.Modifier, b); err != nil {
+				return
+			}
+		case 'A':
+			if weekday, j, err = parseAny(source, j, longWeekNames, 'A'); err != nil {
+				return
+			}
+		case 'a':
+			if weekday, j, err = parseAny(source, j, shortWeekNames, 'a'); err != nil {
+				return
+			}
+		case 'w':
+			if weekday, j, err = parseNumber(source, j, token.Modifier, b); err != nil {
+				return
+			}
+			weekday++
+		case 'u':
+			if weekday, j, err = parseNumber(source, j, token.Modifier, b); err != nil {
+				return
+			}
+			weekday = weekday%7 + 1
+		case 'V':
+			if week, j, err = parseNumber(source, j, token.Modifier, b); err != nil {
+				return
+			}
+			weekstart = time.Thursday
+			weekday = or(weekday, 2)
+		case 'U':
+			if week, j, err = parseNumber(source, j, token.Modifier, b); err != nil {
+				return
+			}
+			weekstart = time.Sunday
+			weekday = or(weekday, 1)
+		case 'W':
+			if week, j, err = parseNumber(source, j, token.Modifier, b); err != nil {
+				return
+			}
+			weekstart = time.Monday
+			weekday = or(weekday, 2)
+		case 'e':
+			if j < l && source[j] == ' ' {
+				j++
+			}
+			fallthrough
+		case 'd':
+			if day, j, err = parseNumber(source, j, token.Modifier, b); err != nil {
+				return
+			}
+		case 'o':
+			if day, j, err = parseNumber(source, j, token.Modifier, b); err != nil {
+				return
+			}
+			if _, j, err = parseAny(source, j, []string{lc.ordinalSuffix(day)}, 'o'); err != nil {
+				return
+			}
+		case 'j':
+			if yday, j, err = parseNumber(source, j, token.Modifier, b); err != nil {
+				return
+			}
+		case 'k':
+			if j < l && source[j] == ' ' {
+				j++
+			}
+			fallthrough
+		case 'H':
+			if hour, j, err = parseNumber(source, j, token.Modifier, b); err != nil {
+				return
+			}
+		case 'l':
+			if j < l && source[j] == ' ' {
+				j++
+			}
+			fallthrough
+		case 'I':
+			if hour, j, err = parseNumber(source, j, token.Modifier, b); err != nil {
+				return
+			}
+			if hour == 12 {
+				hour = 0
+			}
+		case 'P', 'p':
+			var ampm int
+			if ampm, j, err = parseAny(source, j, []string{"AM", "PM"}, b); err != nil {
+				return
+			}
+			pm = ampm == 2
+		case 'M':
+			if minute, j, err = parseNumber(source, j, token.Modifier, b); err != nil {
+				return
+			}
+		case 'S':
+			if second, j, err = parseNumber(source, j, token.Modifier, b); err != nil {
+				return
+			}
+		case 's':
+			sign, j = parseSign(source, j, l)
+			var unix int64
+			if unix, j, err = parseInt64(source, j, 19, 's'); err != nil {
+				return
+			}
+			t = time.Unix(int64(sign)*unix, 0).In(time.UTC)
+			var mon time.Month
+			year, mon, day = t.Date()
+			hour, minute, second = t.Clock()
+			month, hasMonth, hasYear = int(mon), true, true
+		case 'f':
+			fraction = true
+		case 'Z':
+			i := j
+			for ; j < l; j++ {
+				if c := source[j]; c < 'A' || 'Z' < c {
+					break
 				}
+			}
+			t, err = time.ParseInLocation("MST", source[i:j], base)
+			if err != nil {
+				err = fmt.Errorf(`cannot parse %q with "%%Z"`, source[i:j])
+				return
+			}
+			if hasZoneOffset {
+				name, _ := t.Zone()
+				_, offset := locationZone(loc)
+				loc = time.FixedZone(name, offset)
+			} else {
+				loc = t.Location()
+			}
+			hasZoneName = true
+		case 'z':
+			colons := token.Colons
+			if j >= l {
+				err = parseZFormatError(colons)
+				return
+			}
+			sign = 1
+			switch source[j] {
+			case '-':
+				sign = -1
 				fallthrough
-			case 'I':
-				if hour, j, err = parseInt(source, j, 2, 1, 12, b); err != nil {
-					return
-				}
-				if hour == 12 {
-					hour = 0
-				}
-			case 'P', 'p':
-				var ampm int
-				if ampm, j, err = parseAny(source, j, []string{"AM", "PM"}, b); err != nil {
-					return
-				}
-				pm = ampm == 2
-			case 'M':
-				if minute, j, err = parseInt(source, j, 2, 0, 59, 'M'); err != nil {
-					return
-				}
-			case 'S':
-				if second, j, err = parseInt(source, j, 2, 0, 60, 'S'); err != nil {
-					return
-				}
-			case 's':
-				sign, j = parseSign(source, j, l)
-				var unix int64
-				if unix, j, err = parseInt64(source, j, 19, 's'); err != nil {
-					return
-				}
-				t = time.Unix(int64(sign)*unix, 0).In(time.UTC)
-				var mon time.Month
-				year, mon, day = t.Date()
-				hour, minute, second = t.Clock()
-				month = int(mon)
-			case 'f':
-				microsecond, i := 0, j
-				if microsecond, j, err = parseInt(source, j, 6, 0, 999999, 'f'); err != nil {
+			case '+':
+				hour, minute, second, i := 0, 0, 0, j+1
+				if hour, j, _ = parseInt(source, i, 2, 0, 23, 'z'); j != i+2 {
+					err = parseZFormatError(colons)
 					return
 				}
-				for i = j - i; i < 6; i++ {
-					microsecond *= 10
-				}
-				nanosecond = microsecond * 1000
-			case 'Z':
-				i := j
-				for ; j < l; j++ {
-					if c := source[j]; c < 'A' || 'Z' < c {
-						break
+				if j >= l || source[j] != ':' {
+					if colons > 0 && colons < 3 {
+						err = expectedColonForZFormatError(colons)
+						return
 					}
+				} else if j++; colons == 0 {
+					colons = 4
 				}
-				t, err = time.ParseInLocation("MST", source[i:j], base)
-				if err != nil {
-					err = fmt.Errorf(`cannot parse %q with "%%Z"`, source[i:j])
-					return
-				}
-				if hasZoneOffset {
-					name, _ := t.Zone()
-					_, offset := locationZone(loc)
-					loc = time.FixedZone(name, offset)
-				} else {
-					loc = t.Location()
-				}
-				hasZoneName = true
-			case 'z':
-				if j >= l {
-					err = parseZFormatError(colons)
-					return
-				}
-				sign = 1
-				switch source[j] {
-				case '-':
-					sign = -1
-					fallthrough
-				case '+':
-					hour, minute, second, i := 0, 0, 0, j+1
-					if hour, j, _ = parseInt(source, i, 2, 0, 23, 'z'); j != i+2 {
-						err = parseZFormatError(colons)
+				i = j
+				if minute, j, _ = parseInt(source, i, 2, 0, 59, 'z'); j != i+2 {
+					if colons > 0 && colons != 3 {
+						err = parseZFormatError(colons & 3)
 						return
 					}
+					j = i
+				} else if colons > 1 {
 					if j >= l || source[j] != ':' {
-						if colons > 0 && colons < 3 {
+						if colons < 3 {
 							err = expectedColonForZFormatError(colons)
 							return
 						}
-					} else if j++; colons == 0 {
-						colons = 4
-					}
-					i = j
-					if minute, j, _ = parseInt(source, i, 2, 0, 59, 'z'); j != i+2 {
-						if colons > 0 && colons != 3 {
-							err = parseZFormatError(colons & 3)
-							return
-						}
-						j = i
-					} else if colons > 1 {
-						if j >= l || source[j] != ':' {
+					} else {
+						i = j + 1
+						if second, j, _ = parseInt(source, i, 2, 0, 59, 'z'); j != i+2 {
 							if colons < 3 {
-								err = expectedColonForZFormatError(colons)
+								err = parseZFormatError(colons)
 								return
 							}
-						} else {
-							i = j + 1
-							if second, j, _ = parseInt(source, i, 2, 0, 59, 'z'); j != i+2 {
-								if colons < 3 {
-									err = parseZFormatError(colons)
-									return
-								}
-								j = i - 1
-							}
+							j = i - 1
 						}
 					}
-					var name string
-					if hasZoneName {
-						name, _ = locationZone(loc)
-					}
-					loc, colons = time.FixedZone(name, sign*((hour*60+minute)*60+second)), 0
-					hasZoneOffset = true
-				case 'Z':
-					loc, colons, j = time.UTC, 0, j+1
-				default:
-					err = parseZFormatError(colons)
-					return
 				}
-			case ':':
-				if pending != "" {
-					if j >= l || source[j] != b {
-						err = expectedFormatError(b)
-						return
-					}
-					j++
-				} else {
-					for colons = 1; colons <= 3; colons++ {
-						if i++; i == len(format) {
-							break
-						} else if b = format[i]; b == 'z' {
-							goto L
-						} else if b != ':' || colons == 3 {
-							break
-						}
-					}
-					err = expectedZAfterColonError(colons)
-					return
-				}
-			case 't', 'n':
-				i := j
-			K:
-				for ; j < l; j++ {
-					switch source[j] {
-					case ' ', '\t', '\n', '\v', '\f', '\r':
-					default:
-						break K
-					}
-				}
-				if i == j {
-					err = fmt.Errorf(`expected a space for "%%%c"`, b)
-					return
+				var name string
+				if hasZoneName {
+					name, _ = locationZone(loc)
 				}
-			case '%':
-				if j >= l || source[j] != b {
-					err = expectedFormatError(b)
-					return
-				}
-				j++
-			case 'c':
-				pending = "a b e H:M:S Y"
-			case '+':
-				pending = "a b e H:M:S Z Y"
-			case 'v':
-				pending = "e-b-Y"
-			case 'r':
-				pending = "I:M:S p"
-			case 'F':
-				pending = "Y-m-d"
-			case 'D', 'x':
-				pending = "m/d/y"
-			case 'T', 'X':
-				pending = "H:M:S"
-			case 'R':
-				pending = "H:M"
+				loc = time.FixedZone(name, sign*((hour*60+minute)*60+second))
+				hasZoneOffset = true
+			case 'Z':
+				loc, j = time.UTC, j+1
 			default:
-				if pending == "" {
-					err = fmt.Errorf(`unexpected format "%%%c"`, b)
-					return
-				}
-				if j >= l || source[j] != b {
-					err = expectedFormatError(b)
-					return
+				err = parseZFormatError(colons)
+				return
+			}
+		case ':':
+			err = expectedZAfterColonError(min(token.Colons, 3))
+			return
+		case 't', 'n':
+			i := j
+		K:
+			for ; j < l; j++ {
+				switch source[j] {
+				case ' ', '\t', '\n', '\v', '\f', '\r':
+				default:
+					break K
 				}
-				j++
 			}
-			if pending != "" {
-				b, pending = pending[0], pending[1:]
-				goto L
+			if i == j {
+				err = fmt.Errorf(`expected a space for "%%%c"`, b)
+				return
+			}
+		case '%':
+			if j >= l || source[j] != b {
+				err = expectedFormatError(b)
+				return
 			}
-		} else if j >= l || source[j] != b {
-			err = expectedFormatError(b)
-			return
-		} else {
 			j++
+		default:
+			err = fmt.Errorf(`unexpected format "%%%c"`, b)
+			return
 		}
 	}
-	if j < len(source) {
+	if fraction {
+		if nanosecond, j, err = parseFraction(source, j, false); err != nil {
+			return
+		}
+	}
+	if j < len(source) && !prefix {
 		err = fmt.Errorf("unparsed string %q", source[j:])
 		return
 	}
@@ -351,13 +413,60 @@ func parse(source, format string, loc, base *time.Location) (t time.Time, err er
 	if century >= 0 {
 		year = century*100 + year%100
 	}
+	if quarter > 0 {
+		if !hasMonth {
+			month, hasMonth = quarter*3-2, true
+		} else if (month+2)/3 != quarter {
+			err = errors.New(`month does not belong to the quarter of "%q"`)
+			return
+		}
+	}
+	if half > 0 {
+		if !hasMonth {
+			month = half*6 - 5
+		} else if (month+5)/6 != half {
+			err = errors.New(`month does not belong to the half year of "%Q"`)
+			return
+		}
+	}
+	if hasFiscalYear || fiscalQuarter+fiscalPeriod+fiscalWeek > 0 {
+		if !hasFiscalYear {
+			err = errors.New(`use "%EY" to parse fiscal quarter, period or week`)
+			return
+		}
+		if day == 0 && yday == 0 && !hasMonth {
+			if fiscalWeek > 0 {
+				t = cal.YearStart(fiscalYear, loc).AddDate(0, 0, (fiscalWeek-1)*7)
+				if weekday > 0 {
+					t = t.AddDate(0, 0, (weekday-1-int(t.Weekday())+7)%7)
+				}
+			} else if fiscalPeriod > 0 {
+				t = cal.periodStart(fiscalYear, fiscalPeriod, loc)
+			} else {
+				t = cal.periodStart(fiscalYear, max(fiscalQuarter*3-2, 1), loc)
+			}
+			year, mon, day := t.Date()
+			return time.Date(year, mon, day, hour, minute, second, nanosecond, loc), j, nil
+		}
+		if !hasYear {
+			if yday > 0 || !hasMonth {
+				err = errors.New(`use "%m" to parse day of fiscal year`)
+				return
+			}
+			var ok bool
+			if year, ok = cal.calendarYear(fiscalYear, time.Month(month), day); !ok {
+				err = errors.New(`date does not belong to the fiscal year of "%EY"`)
+				return
+			}
+		}
+	}
 	if day == 0 {
 		if yday > 0 {
 			if hasISOYear {
 				err = errors.New(`use "%Y" to parse non-ISO year for "%j"`)
 				return
 			}
-			return time.Date(year, time.January, yday, hour, minute, second, nanosecond, loc), nil
+			return time.Date(year, time.January, yday, hour, minute, second, nanosecond, loc), j, nil
 		}
 		if weekstart >= time.Sunday {
 			if weekstart == time.Thursday {
@@ -373,11 +482,11 @@ func parse(source, format string, loc, base *time.Location) (t time.Time, err er
 				week++
 			}
 			t := time.Date(year, time.January, -int(weekstart), hour, minute, second, nanosecond, loc)
-			return t.AddDate(0, 0, week*7-int(t.Weekday())+weekday-1), nil
+			return t.AddDate(0, 0, week*7-int(t.Weekday())+weekday-1), j, nil
 		}
 		day = 1
 	}
-	return time.Date(year, time.Month(month), day, hour, minute, second, nanosecond, loc), nil
+	return time.Date(year, time.Month(month), day, hour, minute, second, nanosecond, loc), j, nil
 }
 
 func locationZone(loc *time.Location) (name string, offset int) {
@@ -424,6 +533,30 @@ func parseSign(source string, index, l int) (int, int) {
 // parseInt parses an integer from source. This is intentionally not a
 // wrapper of parseInt64 to avoid the overhead of int64 arithmetic and
 // function call indirection in the hot path (~20% slower in benchmarks).
+// parseNumber parses the digits of the numeric directive within the range of
+// the directive.
+func parseNumber(source string, index int, modifier, directive byte) (int, int, error) {
+	info, _ := lookupDirective(modifier, directive)
+	return parseInt(source, index, info.digits(), max(info.Min, 0), info.Max, directive)
+}
+
+// parseFraction parses the fraction of second up to nanoseconds, or up to
+// microseconds if micro is true, and returns the value in nanoseconds.
+func parseFraction(source string, index int, micro bool) (int, int, error) {
+	digits := 9
+	if micro {
+		digits = 6
+	}
+	nanosecond, i, err := parseInt(source, index, digits, 0, 999999999, 'f')
+	if err != nil {
+		return 0, 0, err
+	}
+	for n := i - index; n < 9; n++ {
+		nanosecond *= 10
+	}
+	return nanosecond, i, nil
+}
+
 func parseInt(source string, index, size, minimum, maximum int, format byte) (int, int, error) {
 	var value int
 	i := index
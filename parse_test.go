@@ -247,6 +247,76 @@ var parseTestCases = []struct {
 		format:   "%Y %m %e",
 		parseErr: errors.New(`cannot parse "%e"`),
 	},
+	{
+		source: "July 24th, 2020",
+		format: "%B %o, %Y",
+		t:      time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "1ST 2nd 3Rd 11th 22nd",
+		format: "%o %o %o %o %o",
+		t:      time.Date(1900, time.January, 22, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source:   "July 24st, 2020",
+		format:   "%B %o, %Y",
+		parseErr: errors.New(`cannot parse "%o"`),
+	},
+	{
+		source:   "July 24, 2020",
+		format:   "%B %o, %Y",
+		parseErr: errors.New(`cannot parse "%o"`),
+	},
+	{
+		source:   "July 32nd, 2020",
+		format:   "%B %o, %Y",
+		parseErr: errors.New(`cannot parse "%o"`),
+	},
+	{
+		source: "2020-Q3",
+		format: "%Y-Q%q",
+		t:      time.Date(2020, time.July, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "2020-Q4",
+		format: "%G-Q%q",
+		t:      time.Date(2020, time.October, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "2020-H2",
+		format: "%Y-H%Q",
+		t:      time.Date(2020, time.July, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "Q2 2020-05-24",
+		format: "Q%q %F",
+		t:      time.Date(2020, time.May, 24, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "2020-08 H2",
+		format: "%Y-%m H%Q",
+		t:      time.Date(2020, time.August, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source:   "Q2 2020-07-24",
+		format:   "Q%q %F",
+		parseErr: errors.New(`month does not belong to the quarter of "%q"`),
+	},
+	{
+		source:   "2020-01 H2",
+		format:   "%Y-%m H%Q",
+		parseErr: errors.New(`month does not belong to the half year of "%Q"`),
+	},
+	{
+		source:   "2020-Q5",
+		format:   "%Y-Q%q",
+		parseErr: errors.New(`cannot parse "%q"`),
+	},
+	{
+		source:   "2020-H3",
+		format:   "%Y-H%Q",
+		parseErr: errors.New(`cannot parse "%Q"`),
+	},
 	{
 		source: "Jan",
 		format: "%b",
@@ -747,6 +817,16 @@ var parseTestCases = []struct {
 		format: "%H:%M:%S.%f",
 		t:      time.Date(1900, time.January, 1, 23, 59, 59, 999999000, time.UTC),
 	},
+	{
+		source: "23:59:59.123456789",
+		format: "%H:%M:%S.%f",
+		t:      time.Date(1900, time.January, 1, 23, 59, 59, 123456789, time.UTC),
+	},
+	{
+		source: "23:59:59.1234567+0900",
+		format: "%H:%M:%S.%f%z",
+		t:      time.Date(1900, time.January, 1, 23, 59, 59, 123456700, time.FixedZone("", 9*60*60)),
+	},
 	{
 		source: "23:59:60",
 		format: "%H:%M:%S",
@@ -1330,6 +1410,26 @@ var parseTestCases = []struct {
 		format:   "%",
 		parseErr: errors.New(`stray "%"`),
 	},
+	{
+		format:   "%5",
+		parseErr: errors.New(`unexpected format "%5"`),
+	},
+	{
+		format:   "%-d",
+		parseErr: errors.New(`unexpected format "%-"`),
+	},
+	{
+		format:   "%^c",
+		parseErr: errors.New(`unexpected format "%^"`),
+	},
+	{
+		format:   "%EY",
+		parseErr: errors.New(`unexpected format "%E"`),
+	},
+	{
+		format:   "%::::z",
+		parseErr: errors.New(`expected 'z' after "%:::"`),
+	},
 	{
 		source:   "",
 		format:   "%%",
@@ -1373,6 +1473,23 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestParseError(t *testing.T) {
+	_, err := timefmt.Parse("2020-07-24 25:00", "%Y-%m-%d %H:%M")
+	var pe *timefmt.ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("expected ParseError but got: %#v", err)
+	}
+	if pe.Source != "2020-07-24 25:00" || pe.Format != "%Y-%m-%d %H:%M" {
+		t.Errorf("unexpected source or format: %#v", pe)
+	}
+	if expected := `cannot parse "%H"`; pe.Err.Error() != expected {
+		t.Errorf("expected: %q, got: %q", expected, pe.Err.Error())
+	}
+	if expected := `failed to parse "2020-07-24 25:00" with "%Y-%m-%d %H:%M": cannot parse "%H"`; err.Error() != expected {
+		t.Errorf("expected: %q, got: %q", expected, err.Error())
+	}
+}
+
 func ExampleParse() {
 	str := "2020-07-24 09:07:29"
 	t, err := timefmt.Parse(str, "%Y-%m-%d %H:%M:%S")
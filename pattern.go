@@ -0,0 +1,207 @@
+package timefmt
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Pattern is a compiled format of the paths of rotated files, like
+// /var/log/app/%Y/%m/%d/app-%H.log.
+type Pattern struct {
+	format string
+	loc    *time.Location
+	unit   Unit
+	regexp *regexp.Regexp
+	glob   string
+}
+
+// NewPattern compiles the format into a [Pattern]. The times are formatted
+// and parsed in the location. The sub-second directive %f is not supported.
+func NewPattern(format string, loc *time.Location) (*Pattern, error) {
+	p := &Pattern{format: format, loc: loc}
+	var sb strings.Builder
+	for _, token := range scanFormat(format) {
+		if token.Directive == 0 {
+			sb.WriteString(globEscaper.Replace(token.Literal))
+			continue
+		}
+		unit, width, err := directiveUnit(token)
+		if err != nil {
+			return nil, err
+		}
+		if unit != 0 && (p.unit == 0 || unit < p.unit) {
+			p.unit = unit
+		}
+		switch {
+		case width > 0:
+			sb.WriteString(strings.Repeat("[0-9]", width))
+		case token.Directive == 't':
+			sb.WriteByte('\t')
+		case token.Directive == 'n':
+			sb.WriteByte('\n')
+		default:
+			sb.WriteByte('*')
+		}
+	}
+	p.glob = sb.String()
+	pattern, err := Regexp(format)
+	if err != nil {
+		return nil, err
+	}
+	p.regexp = regexp.MustCompile("^(?:" + pattern + ")$")
+	return p, nil
+}
+
+var globEscaper = strings.NewReplacer("*", "[*]", "?", "[?]", "[", "[[]", `\`, `\\`)
+
+// directiveUnit returns the smallest unit at which the output of the directive
+// changes, and the width of the digits if it is fixed.
+func directiveUnit(token Token) (Unit, int, error) {
+	if token.Modifier != 0 {
+		return 0, 0, fmt.Errorf("unsupported directive %q", token.Literal)
+	}
+	var width int
+	if info, ok := lookupDirective(0, token.Directive); ok && info.Numeric && info.Max > 0 &&
+		!spacePadded(token.Directive) && (token.Padding == 0 || token.Padding == '0') {
+		width = max(token.Width, info.digits())
+	}
+	switch token.Directive {
+	case 'Y', 'y', 'C':
+		return UnitYear, width, nil
+	case 'm', 'q', 'Q', 'B', 'b', 'h':
+		return UnitMonth, width, nil
+	case 'G', 'g', 'V', 'U', 'W':
+		return UnitWeek, width, nil
+	case 'd', 'e', 'o', 'j', 'A', 'a', 'w', 'u':
+		return UnitDay, width, nil
+	case 'H', 'k', 'I', 'l', 'p', 'P':
+		return UnitHour, width, nil
+	case 'M':
+		return UnitMinute, width, nil
+	case 'S', 's':
+		return UnitSecond, width, nil
+	case 'z', 'Z', 't', 'n', '%':
+		return 0, 0, nil
+	case 'f':
+		return 0, 0, errors.New("sub-second directive %f is not supported")
+	default:
+		return 0, 0, fmt.Errorf("unsupported directive %q", token.Literal)
+	}
+}
+
+// Format returns the path of the time.
+func (p *Pattern) Format(t time.Time) string {
+	return Format(t.In(p.loc), p.format)
+}
+
+// Unit returns the smallest unit of the directives in the pattern, or zero if
+// the pattern has no directives of time.
+func (p *Pattern) Unit() Unit {
+	return p.unit
+}
+
+// Next returns the earliest time after t at which the formatted path changes.
+// It returns the zero time if the path never changes.
+func (p *Pattern) Next(t time.Time) time.Time {
+	if p.unit == 0 {
+		return time.Time{}
+	}
+	t = t.In(p.loc)
+	path := Format(t, p.format)
+	for range 1000 {
+		if t = p.step(t); Format(t, p.format) != path {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// step returns the start of the next unit of the pattern.
+func (p *Pattern) step(t time.Time) time.Time {
+	year, month, day := t.Date()
+	var u time.Time
+	switch p.unit {
+	case UnitSecond:
+		return t.Truncate(time.Second).Add(time.Second)
+	case UnitMinute:
+		return t.Truncate(time.Minute).Add(time.Minute)
+	case UnitHour:
+		t = t.Truncate(time.Minute)
+		return t.Add(time.Duration(60-t.Minute()) * time.Minute)
+	case UnitDay, UnitWeek:
+		u = time.Date(year, month, day+1, 0, 0, 0, 0, p.loc)
+	case UnitMonth:
+		u = time.Date(year, month+1, 1, 0, 0, 0, 0, p.loc)
+	default:
+		u = time.Date(year+1, time.January, 1, 0, 0, 0, 0, p.loc)
+	}
+	// the midnight may be skipped by the daylight saving time
+	for !u.After(t) {
+		u = u.Add(time.Hour)
+	}
+	return u
+}
+
+// Glob returns the pattern for [path/filepath.Glob], which matches the paths
+// formatted by the pattern.
+func (p *Pattern) Glob() string {
+	return p.glob
+}
+
+// Match reports whether the path is formatted by the pattern, and returns the
+// time embedded in the path.
+func (p *Pattern) Match(path string) (time.Time, bool) {
+	if !p.regexp.MatchString(path) {
+		return time.Time{}, false
+	}
+	t, err := ParseInLocation(path, p.format, p.loc)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// Enumerate returns the distinct strings formatted by the format for the times
+// in [start, end), in the order of their first appearances. The times are
+// stepped by the smallest unit of the directives in the location, so the
+// strings are enumerated correctly through the daylight saving time and the
+// lengths of months.
+func Enumerate(format string, start, end time.Time, loc *time.Location) ([]string, error) {
+	p, err := NewPattern(format, loc)
+	if err != nil {
+		return nil, err
+	}
+	var values []string
+	seen := make(map[string]struct{})
+	for t := start; !t.IsZero() && t.Before(end); t = p.Next(t) {
+		value := p.Format(t)
+		if _, ok := seen[value]; !ok {
+			seen[value] = struct{}{}
+			values = append(values, value)
+		}
+	}
+	return values, nil
+}
+
+// Bounds parses the source with the format, and returns the half-open interval
+// [start, end) represented by the source, based on the smallest unit of the
+// directives in the format. For example, 2020-07 with %Y-%m represents the
+// whole of July 2020.
+func Bounds(source, format string, loc *time.Location) (start, end time.Time, err error) {
+	p, err := NewPattern(format, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if p.unit == 0 {
+		return time.Time{}, time.Time{}, fmt.Errorf("no directive of time in %q", format)
+	}
+	if start, err = ParseInLocation(source, format, loc); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	// step in the location of the time zone parsed by %z, %Z or %s
+	p.loc = start.Location()
+	return start, p.Next(start), nil
+}
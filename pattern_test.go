@@ -0,0 +1,400 @@
+package timefmt_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+)
+
+var patternNextTestCases = []struct {
+	format   string
+	t        time.Time
+	unit     timefmt.Unit
+	expected time.Time
+}{
+	{
+		format:   "/var/log/app/%Y/%m/%d/app-%H.log",
+		t:        time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC),
+		unit:     timefmt.UnitHour,
+		expected: time.Date(2020, time.July, 24, 10, 0, 0, 0, time.UTC),
+	},
+	{
+		format:   "app-%Y%m%d.log",
+		t:        time.Date(2020, time.December, 31, 23, 59, 59, 999999999, time.UTC),
+		unit:     timefmt.UnitDay,
+		expected: time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		format:   "app-%Y%m%d%H%M%S.log",
+		t:        time.Date(2020, time.July, 24, 9, 7, 29, 500000000, time.UTC),
+		unit:     timefmt.UnitSecond,
+		expected: time.Date(2020, time.July, 24, 9, 7, 30, 0, time.UTC),
+	},
+	{
+		format:   "app-%Y-%m-%dT%H:%M.log",
+		t:        time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC),
+		unit:     timefmt.UnitMinute,
+		expected: time.Date(2020, time.July, 24, 9, 8, 0, 0, time.UTC),
+	},
+	{
+		format:   "app-%Y-Q%q.log",
+		t:        time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC),
+		unit:     timefmt.UnitMonth,
+		expected: time.Date(2020, time.October, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		format:   "app-%G-W%V.log",
+		t:        time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC),
+		unit:     timefmt.UnitWeek,
+		expected: time.Date(2020, time.July, 27, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		format:   "app-%Y.log",
+		t:        time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC),
+		unit:     timefmt.UnitYear,
+		expected: time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		format:   "app-%F-%p.log",
+		t:        time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC),
+		unit:     timefmt.UnitHour,
+		expected: time.Date(2020, time.July, 24, 12, 0, 0, 0, time.UTC),
+	},
+	{
+		format:   "app-%H.log",
+		t:        time.Date(2020, time.July, 24, 9, 45, 0, 0, time.FixedZone("IST", (5*60+30)*60)),
+		unit:     timefmt.UnitHour,
+		expected: time.Date(2020, time.July, 24, 10, 0, 0, 0, time.FixedZone("IST", (5*60+30)*60)),
+	},
+	{
+		format:   "app.log",
+		t:        time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC),
+		unit:     0,
+		expected: time.Time{},
+	},
+}
+
+func TestPatternNext(t *testing.T) {
+	for _, tc := range patternNextTestCases {
+		t.Run(tc.format, func(t *testing.T) {
+			p, err := timefmt.NewPattern(tc.format, tc.t.Location())
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+			if got := p.Unit(); got != tc.unit {
+				t.Errorf("expected: %v, got: %v", tc.unit, got)
+			}
+			if got := p.Next(tc.t); !got.Equal(tc.expected) {
+				t.Errorf("expected: %v, got: %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestPatternGlob(t *testing.T) {
+	dir := t.TempDir()
+	p, err := timefmt.NewPattern(filepath.Join(dir, "%Y", "%m", "app-%d-%a[%H].log"), time.UTC)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if got, expected := p.Glob(), filepath.Join(dir, "[0-9][0-9][0-9][0-9]", "[0-9][0-9]",
+		"app-[0-9][0-9]-*[[][0-9][0-9]].log"); got != expected {
+		t.Errorf("expected: %q, got: %q", expected, got)
+	}
+	var expected []time.Time
+	for i, tm := 0, time.Date(2020, time.July, 30, 22, 0, 0, 0, time.UTC); i < 5; i++ {
+		path := p.Format(tm)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		expected = append(expected, tm)
+		tm = p.Next(tm)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "2020", "07", "app-31-Fri[00].log.gz"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	paths, err := filepath.Glob(p.Glob())
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	var got []time.Time
+	for _, path := range paths {
+		if tm, ok := p.Match(path); ok {
+			got = append(got, tm)
+		}
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected: %v, got: %v", expected, got)
+	}
+}
+
+func TestPatternMatch(t *testing.T) {
+	p, err := timefmt.NewPattern("/var/log/app/%Y/%m/%d/app-%H.log", time.UTC)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	for _, tc := range []struct {
+		path     string
+		expected time.Time
+		ok       bool
+	}{
+		{"/var/log/app/2020/07/24/app-09.log", time.Date(2020, time.July, 24, 9, 0, 0, 0, time.UTC), true},
+		{"/var/log/app/2020/7/24/app-09.log", time.Time{}, false},
+		{"/var/log/app/2020/07/24/app-24.log", time.Time{}, false},
+		{"/var/log/app/2020/07/24/app-09.log.gz", time.Time{}, false},
+	} {
+		got, ok := p.Match(tc.path)
+		if ok != tc.ok || !got.Equal(tc.expected) {
+			t.Errorf("expected: %v, %v, got: %v, %v", tc.expected, tc.ok, got, ok)
+		}
+	}
+}
+
+func TestPatternError(t *testing.T) {
+	for _, tc := range []struct {
+		format, expected string
+	}{
+		{"app-%F-%f.log", "sub-second directive %f is not supported"},
+		{"app-%F-%E.log", `unsupported directive "%E"`},
+	} {
+		if _, err := timefmt.NewPattern(tc.format, time.UTC); err == nil || err.Error() != tc.expected {
+			t.Errorf("expected error %q but got: %v", tc.expected, err)
+		}
+	}
+}
+
+func ExamplePattern() {
+	p, _ := timefmt.NewPattern("/var/log/app/%Y/%m/%d/app-%H.log", time.UTC)
+	t := time.Date(2020, time.July, 24, 23, 7, 29, 0, time.UTC)
+	fmt.Println(p.Format(t))
+	fmt.Println(p.Next(t))
+	fmt.Println(p.Glob())
+	fmt.Println(p.Match("/var/log/app/2020/07/25/app-00.log"))
+	// Output:
+	// /var/log/app/2020/07/24/app-23.log
+	// 2020-07-25 00:00:00 +0000 UTC
+	// /var/log/app/[0-9][0-9][0-9][0-9]/[0-9][0-9]/[0-9][0-9]/app-[0-9][0-9].log
+	// 2020-07-25 00:00:00 +0000 UTC true
+}
+
+var enumerateTestCases = []struct {
+	name       string
+	format     string
+	start, end time.Time
+	expected   []string
+}{
+	{
+		name:   "days",
+		format: "dt=%Y-%m-%d",
+		start:  time.Date(2020, time.February, 27, 12, 0, 0, 0, time.UTC),
+		end:    time.Date(2020, time.March, 2, 0, 0, 0, 0, time.UTC),
+		expected: []string{
+			"dt=2020-02-27", "dt=2020-02-28", "dt=2020-02-29", "dt=2020-03-01",
+		},
+	},
+	{
+		name:   "hours",
+		format: "dt=%Y-%m-%d/hr=%H",
+		start:  time.Date(2020, time.July, 24, 22, 30, 0, 0, time.UTC),
+		end:    time.Date(2020, time.July, 25, 1, 0, 0, 0, time.UTC),
+		expected: []string{
+			"dt=2020-07-24/hr=22", "dt=2020-07-24/hr=23", "dt=2020-07-25/hr=00",
+		},
+	},
+	{
+		name:   "months",
+		format: "%Y/%m",
+		start:  time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC),
+		end:    time.Date(2020, time.May, 1, 0, 0, 0, 0, time.UTC),
+		expected: []string{
+			"2020/01", "2020/02", "2020/03", "2020/04",
+		},
+	},
+	{
+		name:   "repeated values",
+		format: "hr=%H",
+		start:  time.Date(2020, time.July, 24, 22, 0, 0, 0, time.UTC),
+		end:    time.Date(2020, time.July, 26, 0, 0, 0, 0, time.UTC),
+		expected: []string{
+			"hr=22", "hr=23", "hr=00", "hr=01", "hr=02", "hr=03", "hr=04", "hr=05", "hr=06",
+			"hr=07", "hr=08", "hr=09", "hr=10", "hr=11", "hr=12", "hr=13", "hr=14", "hr=15",
+			"hr=16", "hr=17", "hr=18", "hr=19", "hr=20", "hr=21",
+		},
+	},
+	{
+		name:     "constant",
+		format:   "all",
+		start:    time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+		end:      time.Date(2020, time.July, 26, 0, 0, 0, 0, time.UTC),
+		expected: []string{"all"},
+	},
+	{
+		name:     "empty range",
+		format:   "%F",
+		start:    time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+		end:      time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+		expected: nil,
+	},
+}
+
+func TestEnumerate(t *testing.T) {
+	for _, tc := range enumerateTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := timefmt.Enumerate(tc.format, tc.start, tc.end, time.UTC)
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected: %q, got: %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestEnumerateDaylightSavingTime(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	for _, tc := range []struct {
+		start, end time.Time
+		expected   []string
+	}{
+		{
+			start:    time.Date(2020, time.March, 8, 0, 0, 0, 0, loc),
+			end:      time.Date(2020, time.March, 8, 5, 0, 0, 0, loc),
+			expected: []string{"03-08T00 -0500", "03-08T01 -0500", "03-08T03 -0400", "03-08T04 -0400"},
+		},
+		{
+			start:    time.Date(2020, time.November, 1, 0, 0, 0, 0, loc),
+			end:      time.Date(2020, time.November, 1, 3, 0, 0, 0, loc),
+			expected: []string{"11-01T00 -0400", "11-01T01 -0400", "11-01T01 -0500", "11-01T02 -0500"},
+		},
+	} {
+		got, err := timefmt.Enumerate("%m-%dT%H %z", tc.start, tc.end, loc)
+		if err != nil {
+			t.Fatalf("expected no error but got: %v", err)
+		}
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("expected: %q, got: %q", tc.expected, got)
+		}
+	}
+}
+
+func ExampleEnumerate() {
+	start := time.Date(2020, time.July, 24, 22, 30, 0, 0, time.UTC)
+	end := start.Add(3 * time.Hour)
+	values, _ := timefmt.Enumerate("dt=%Y-%m-%d/hr=%H", start, end, time.UTC)
+	for _, value := range values {
+		fmt.Println(value)
+	}
+	// Output:
+	// dt=2020-07-24/hr=22
+	// dt=2020-07-24/hr=23
+	// dt=2020-07-25/hr=00
+	// dt=2020-07-25/hr=01
+}
+
+var boundsTestCases = []struct {
+	source, format string
+	start, end     time.Time
+}{
+	{
+		source: "2020",
+		format: "%Y",
+		start:  time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+		end:    time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "2020-07",
+		format: "%Y-%m",
+		start:  time.Date(2020, time.July, 1, 0, 0, 0, 0, time.UTC),
+		end:    time.Date(2020, time.August, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "2020-02-29",
+		format: "%F",
+		start:  time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC),
+		end:    time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "2020-W53",
+		format: "%G-W%V",
+		start:  time.Date(2020, time.December, 28, 0, 0, 0, 0, time.UTC),
+		end:    time.Date(2021, time.January, 4, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "2020-Q3",
+		format: "%Y-Q%q",
+		start:  time.Date(2020, time.July, 1, 0, 0, 0, 0, time.UTC),
+		end:    time.Date(2020, time.October, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "2020-07-24 PM",
+		format: "%F %p",
+		start:  time.Date(2020, time.July, 24, 12, 0, 0, 0, time.UTC),
+		end:    time.Date(2020, time.July, 25, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "2020-07-24 09:07",
+		format: "%F %R",
+		start:  time.Date(2020, time.July, 24, 9, 7, 0, 0, time.UTC),
+		end:    time.Date(2020, time.July, 24, 9, 8, 0, 0, time.UTC),
+	},
+	{
+		source: "2020-07-24 +0900",
+		format: "%Y-%m-%d %z",
+		start:  time.Date(2020, time.July, 24, 0, 0, 0, 0, time.FixedZone("", 9*60*60)),
+		end:    time.Date(2020, time.July, 25, 0, 0, 0, 0, time.FixedZone("", 9*60*60)),
+	},
+	{
+		source: "2020-07 -0330",
+		format: "%Y-%m %z",
+		start:  time.Date(2020, time.July, 1, 0, 0, 0, 0, time.FixedZone("", -(3*60+30)*60)),
+		end:    time.Date(2020, time.August, 1, 0, 0, 0, 0, time.FixedZone("", -(3*60+30)*60)),
+	},
+}
+
+func TestBounds(t *testing.T) {
+	for _, tc := range boundsTestCases {
+		t.Run(tc.source, func(t *testing.T) {
+			start, end, err := timefmt.Bounds(tc.source, tc.format, time.UTC)
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+			if !start.Equal(tc.start) || !end.Equal(tc.end) {
+				t.Errorf("expected: [%v, %v), got: [%v, %v)", tc.start, tc.end, start, end)
+			}
+		})
+	}
+}
+
+func TestBoundsError(t *testing.T) {
+	for _, tc := range []struct {
+		source, format, expected string
+	}{
+		{"2020-13", "%Y-%m", `failed to parse "2020-13" with "%Y-%m": cannot parse "%m"`},
+		{"all", "all", `no directive of time in "all"`},
+	} {
+		if _, _, err := timefmt.Bounds(tc.source, tc.format, time.UTC); err == nil || err.Error() != tc.expected {
+			t.Errorf("expected error %q but got: %v", tc.expected, err)
+		}
+	}
+}
+
+func ExampleBounds() {
+	start, end, _ := timefmt.Bounds("2020-07", "%Y-%m", time.UTC)
+	fmt.Println(start)
+	fmt.Println(end)
+	// Output:
+	// 2020-07-01 00:00:00 +0000 UTC
+	// 2020-08-01 00:00:00 +0000 UTC
+}
@@ -0,0 +1,527 @@
+package timefmt
+
+import (
+	"strings"
+	"time"
+)
+
+// Well-known formats. Formatting and parsing with these formats are optimized
+// by the dedicated implementations, which behave the same as the generic ones.
+// Use [ParseRFC3339], [ParseRFC2822] and [ParseHTTPDate] to handle the quirks
+// of the specifications on parsing.
+const (
+	// RFC3339 is the format of RFC 3339.
+	RFC3339 = "%Y-%m-%dT%H:%M:%S%:z"
+	// RFC2822 is the format of RFC 2822 and RFC 5322.
+	RFC2822 = "%a, %d %b %Y %H:%M:%S %z"
+	// RFC5322 is the same as [RFC2822].
+	RFC5322 = RFC2822
+	// HTTPDate is the IMF-fixdate format of RFC 7231. Note that formatting
+	// with this format does not convert the time to UTC; use [FormatHTTPDate].
+	HTTPDate = "%a, %d %b %Y %H:%M:%S GMT"
+	// RFC3164 is the timestamp format of the BSD syslog protocol.
+	RFC3164 = "%b %e %H:%M:%S"
+	// RFC5424 is the timestamp format of the syslog protocol.
+	RFC5424 = "%Y-%m-%dT%H:%M:%S.%f%:z"
+	// CLF is the timestamp format of the Common Log Format of Apache.
+	CLF = "%d/%b/%Y:%H:%M:%S %z"
+	// ISO8601Basic is the basic format of ISO 8601 date and time.
+	ISO8601Basic = "%Y%m%dT%H%M%S%z"
+	// ISO8601Extended is the extended format of ISO 8601 date and time,
+	// which is the same as [RFC3339].
+	ISO8601Extended = RFC3339
+	// ASCTime is the format of asctime in C.
+	ASCTime = "%a %b %e %H:%M:%S %Y"
+)
+
+// FormatHTTPDate formats the time in UTC using the [HTTPDate] format.
+func FormatHTTPDate(t time.Time) string {
+	return Format(t.UTC(), HTTPDate)
+}
+
+// ParseRFC3339 parses the date and time of RFC 3339. In addition to the
+// [RFC3339] format, it accepts fractional seconds, lower case "t" and "z",
+// and a space as the date and time separator.
+func ParseRFC3339(source string) (time.Time, error) {
+	p := &presetParser{source: source, lenient: true}
+	year, month, day := p.date('-')
+	if !p.accept('T') && !p.accept('t') && !p.accept(' ') {
+		p.failed = true
+	}
+	hour, minute, second := p.clock(':')
+	var nanosecond int
+	if p.accept('.') {
+		nanosecond = p.fraction(9)
+	}
+	loc := p.offset(':')
+	if p.failed || p.i != len(source) {
+		return Parse(source, RFC3339)
+	}
+	return time.Date(year, time.Month(month), day, hour, minute, second, nanosecond, loc), nil
+}
+
+// ParseRFC2822 parses the date and time of RFC 2822 and RFC 5322 including
+// the obsolete syntax. In addition to the [RFC2822] format, it accepts omitted
+// day of week and seconds, two-digit and three-digit years, obsolete zone
+// names like "EST", military zones (treated as UTC), and a trailing comment.
+func ParseRFC2822(source string) (time.Time, error) {
+	p := &presetParser{source: source, lenient: true}
+	if t, ok := p.rfc2822(); ok {
+		return t, nil
+	}
+	return Parse(source, RFC2822)
+}
+
+// ParseHTTPDate parses the HTTP-date of RFC 7231, which is the IMF-fixdate of
+// the [HTTPDate] format, or the obsolete RFC 850 or asctime format. The time
+// is always in UTC.
+func ParseHTTPDate(source string) (time.Time, error) {
+	p := &presetParser{source: source, lenient: true}
+	if t, ok := p.httpDate(); ok {
+		return t, nil
+	}
+	return Parse(source, HTTPDate)
+}
+
+func appendPreset(buf []byte, t time.Time, format string) ([]byte, bool) {
+	switch format {
+	case RFC3339, RFC5424, ISO8601Basic, RFC2822, HTTPDate, CLF, RFC3164, ASCTime:
+	default:
+		return buf, false
+	}
+	year, month, day := t.Date()
+	if year < 0 || 9999 < year {
+		return appendFormat(buf, t, format, nil, nil), true
+	}
+	hour, minute, second := t.Clock()
+	switch format {
+	case RFC3339, RFC5424:
+		buf = appendDate(buf, year, month, day, '-')
+		buf = append(buf, 'T')
+		buf = appendClock(buf, hour, minute, second, ':')
+		if format == RFC5424 {
+			buf = append(buf, '.')
+			buf = appendInt(buf, t.Nanosecond()/1000, 6, '0')
+		}
+		buf = appendOffset(buf, t, ':')
+	case ISO8601Basic:
+		buf = appendDate(buf, year, month, day, 0)
+		buf = append(buf, 'T')
+		buf = appendClock(buf, hour, minute, second, 0)
+		buf = appendOffset(buf, t, 0)
+	case RFC2822, HTTPDate:
+		buf = append(buf, shortWeekNames[t.Weekday()]...)
+		buf = append(buf, ',', ' ')
+		buf = append(buf, smalls[day*2:day*2+2]...)
+		buf = append(buf, ' ')
+		buf = append(buf, shortMonthNames[month-1]...)
+		buf = append(buf, ' ')
+		buf = appendInt(buf, year, 4, '0')
+		buf = append(buf, ' ')
+		buf = appendClock(buf, hour, minute, second, ':')
+		if format == HTTPDate {
+			buf = append(buf, " GMT"...)
+		} else {
+			buf = append(buf, ' ')
+			buf = appendOffset(buf, t, 0)
+		}
+	case CLF:
+		buf = append(buf, smalls[day*2:day*2+2]...)
+		buf = append(buf, '/')
+		buf = append(buf, shortMonthNames[month-1]...)
+		buf = append(buf, '/')
+		buf = appendInt(buf, year, 4, '0')
+		buf = append(buf, ':')
+		buf = appendClock(buf, hour, minute, second, ':')
+		buf = append(buf, ' ')
+		buf = appendOffset(buf, t, 0)
+	default:
+		if format == ASCTime {
+			buf = append(buf, shortWeekNames[t.Weekday()]...)
+			buf = append(buf, ' ')
+		}
+		buf = append(buf, shortMonthNames[month-1]...)
+		buf = append(buf, ' ')
+		buf = appendInt(buf, day, 2, ' ')
+		buf = append(buf, ' ')
+		buf = appendClock(buf, hour, minute, second, ':')
+		if format == ASCTime {
+			buf = append(buf, ' ')
+			buf = appendInt(buf, year, 4, '0')
+		}
+	}
+	return buf, true
+}
+
+func appendDate(buf []byte, year int, month time.Month, day int, sep byte) []byte {
+	buf = appendInt(buf, year, 4, '0')
+	if sep != 0 {
+		buf = append(buf, sep)
+	}
+	buf = append(buf, smalls[month*2:month*2+2]...)
+	if sep != 0 {
+		buf = append(buf, sep)
+	}
+	return append(buf, smalls[day*2:day*2+2]...)
+}
+
+func appendClock(buf []byte, hour, minute, second int, sep byte) []byte {
+	buf = append(buf, smalls[hour*2:hour*2+2]...)
+	if sep != 0 {
+		buf = append(buf, sep)
+	}
+	buf = append(buf, smalls[minute*2:minute*2+2]...)
+	if sep != 0 {
+		buf = append(buf, sep)
+	}
+	return append(buf, smalls[second*2:second*2+2]...)
+}
+
+func appendOffset(buf []byte, t time.Time, sep byte) []byte {
+	_, offset := t.Zone()
+	if offset < 0 {
+		buf = append(buf, '-')
+		offset = -offset
+	} else {
+		buf = append(buf, '+')
+	}
+	buf = appendInt(buf, offset/3600, 2, '0')
+	if sep != 0 {
+		buf = append(buf, sep)
+	}
+	return append(buf, smalls[offset%3600/60*2:offset%3600/60*2+2]...)
+}
+
+// parsePreset parses the source with the well-known format, accepting only
+// the form which the generic parser results in the same time. It reports
+// false to fall back to the generic parser, which also reports the error.
+func parsePreset(source, format string, loc *time.Location) (time.Time, bool) {
+	p := &presetParser{source: source}
+	var year, month, day, hour, minute, second, nanosecond int
+	switch format {
+	case RFC3339, RFC5424:
+		year, month, day = p.date('-')
+		p.expect('T')
+		hour, minute, second = p.clock(':')
+		if format == RFC5424 {
+			p.expect('.')
+			nanosecond = p.fraction(6)
+		}
+		loc = p.offset(':')
+	case ISO8601Basic:
+		year, month, day = p.date(0)
+		p.expect('T')
+		hour, minute, second = p.clock(0)
+		loc = p.offset(0)
+	case RFC2822, HTTPDate:
+		p.name(shortWeekNames)
+		p.expect(',')
+		p.expect(' ')
+		day = p.int(2, 2, 1, 31)
+		p.expect(' ')
+		month = p.name(shortMonthNames)
+		p.expect(' ')
+		year = p.int(4, 4, 0, 9999)
+		p.expect(' ')
+		hour, minute, second = p.clock(':')
+		if format == HTTPDate {
+			if p.failed || p.source[p.i:] != " GMT" {
+				return time.Time{}, false
+			}
+			p.i = len(p.source)
+		} else {
+			p.expect(' ')
+			loc = p.offset(0)
+		}
+	case CLF:
+		day = p.int(2, 2, 1, 31)
+		p.expect('/')
+		month = p.name(shortMonthNames)
+		p.expect('/')
+		year = p.int(4, 4, 0, 9999)
+		p.expect(':')
+		hour, minute, second = p.clock(':')
+		p.expect(' ')
+		loc = p.offset(0)
+	case ASCTime:
+		p.name(shortWeekNames)
+		p.expect(' ')
+		month, day, hour, minute, second = p.syslog()
+		p.expect(' ')
+		year = p.int(4, 4, 0, 9999)
+	case RFC3164:
+		year = 1900
+		month, day, hour, minute, second = p.syslog()
+	default:
+		return time.Time{}, false
+	}
+	if p.failed || p.i != len(source) {
+		return time.Time{}, false
+	}
+	return time.Date(year, time.Month(month), day, hour, minute, second, nanosecond, loc), true
+}
+
+// presetParser is a parser for the well-known formats. Once it fails to
+// parse, the subsequent methods do nothing and return zero values. The lenient
+// parser accepts the quirks of the specifications.
+type presetParser struct {
+	source  string
+	i       int
+	failed  bool
+	lenient bool
+}
+
+func (p *presetParser) accept(b byte) bool {
+	if !p.failed && p.i < len(p.source) && p.source[p.i] == b {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *presetParser) expect(b byte) {
+	if !p.accept(b) {
+		p.failed = true
+	}
+}
+
+func (p *presetParser) spaces() bool {
+	i := p.i
+	for p.accept(' ') || p.accept('\t') {
+	}
+	return p.i > i
+}
+
+func (p *presetParser) int(minDigits, maxDigits, minimum, maximum int) int {
+	if p.failed {
+		return 0
+	}
+	var value int
+	i := p.i
+	for end := min(i+maxDigits, len(p.source)); i < end; i++ {
+		if b := p.source[i] - '0'; b < 10 {
+			value = value*10 + int(b)
+		} else {
+			break
+		}
+	}
+	if i-p.i < minDigits || value < minimum || maximum < value {
+		p.failed = true
+		return 0
+	}
+	p.i = i
+	return value
+}
+
+func (p *presetParser) name(names []string) int {
+	if p.failed {
+		return 0
+	}
+	value, i, err := parseAny(p.source, p.i, names, 0)
+	if err != nil {
+		p.failed = true
+		return 0
+	}
+	p.i = i
+	return value
+}
+
+func (p *presetParser) date(sep byte) (year, month, day int) {
+	year = p.int(4, 4, 0, 9999)
+	if sep != 0 {
+		p.expect(sep)
+	}
+	month = p.int(2, 2, 1, 12)
+	if sep != 0 {
+		p.expect(sep)
+	}
+	day = p.int(2, 2, 1, 31)
+	return
+}
+
+func (p *presetParser) clock(sep byte) (hour, minute, second int) {
+	hour = p.int(2, 2, 0, 23)
+	if sep != 0 {
+		p.expect(sep)
+	}
+	minute = p.int(2, 2, 0, 59)
+	if sep != 0 {
+		p.expect(sep)
+	}
+	second = p.int(2, 2, 0, 60)
+	return
+}
+
+// syslog parses the month, day and time like "Jul  4 09:07:29".
+func (p *presetParser) syslog() (month, day, hour, minute, second int) {
+	month = p.name(shortMonthNames)
+	p.expect(' ')
+	if p.accept(' ') {
+		day = p.int(1, 1, 1, 9)
+	} else {
+		day = p.int(2, 2, 1, 31)
+	}
+	p.expect(' ')
+	hour, minute, second = p.clock(':')
+	return
+}
+
+func (p *presetParser) fraction(digits int) int {
+	i := p.i
+	nanosecond := p.int(1, digits, 0, 999999999)
+	for i = p.i - i; i < 9; i++ {
+		nanosecond *= 10
+	}
+	return nanosecond
+}
+
+func (p *presetParser) offset(sep byte) *time.Location {
+	if p.accept('Z') || p.lenient && p.accept('z') {
+		return time.UTC
+	}
+	sign := 1
+	if p.accept('-') {
+		sign = -1
+	} else {
+		p.expect('+')
+	}
+	hour := p.int(2, 2, 0, 23)
+	if sep != 0 {
+		p.expect(sep)
+	}
+	minute := p.int(2, 2, 0, 59)
+	if p.failed {
+		return nil
+	}
+	return time.FixedZone("", sign*(hour*60+minute)*60)
+}
+
+// rfc2822 parses the date and time of RFC 5322 including the obsolete syntax.
+func (p *presetParser) rfc2822() (time.Time, bool) {
+	p.spaces()
+	if p.i < len(p.source) && p.source[p.i] > '9' {
+		p.name(shortWeekNames)
+		p.spaces()
+		p.expect(',')
+		p.spaces()
+	}
+	day := p.int(1, 2, 1, 31)
+	p.spaces()
+	month := p.name(shortMonthNames)
+	p.spaces()
+	i := p.i
+	year := p.int(2, 4, 0, 9999)
+	switch p.i - i {
+	case 2:
+		if year < 50 {
+			year += 2000
+		} else {
+			year += 1900
+		}
+	case 3:
+		year += 1900
+	}
+	p.spaces()
+	hour := p.int(2, 2, 0, 23)
+	p.expect(':')
+	minute := p.int(2, 2, 0, 59)
+	var second int
+	if p.accept(':') {
+		second = p.int(2, 2, 0, 60)
+	}
+	if !p.spaces() {
+		p.failed = true
+	}
+	var loc *time.Location
+	if p.i < len(p.source) && (p.source[p.i] == '+' || p.source[p.i] == '-') {
+		loc = p.offset(0)
+	} else if !p.failed {
+		i := p.i
+		for ; p.i < len(p.source); p.i++ {
+			if c := p.source[p.i] | 0x20; c < 'a' || 'z' < c {
+				break
+			}
+		}
+		if loc = obsoleteZone(p.source[i:p.i]); loc == nil {
+			p.failed = true
+		}
+	}
+	if p.spaces(); p.accept('(') {
+		for p.i < len(p.source) && p.source[p.i] != ')' {
+			p.i++
+		}
+		p.expect(')')
+		p.spaces()
+	}
+	if p.failed || p.i != len(p.source) {
+		return time.Time{}, false
+	}
+	return time.Date(year, time.Month(month), day, hour, minute, second, 0, loc), true
+}
+
+var obsoleteZoneOffsets = map[string]int{
+	"EST": -5, "EDT": -4,
+	"CST": -6, "CDT": -5,
+	"MST": -7, "MDT": -6,
+	"PST": -8, "PDT": -7,
+}
+
+func obsoleteZone(name string) *time.Location {
+	// the obsolete zones are case-insensitive
+	name = strings.ToUpper(name)
+	if name == "UT" || name == "GMT" {
+		return time.UTC
+	}
+	if offset, ok := obsoleteZoneOffsets[name]; ok {
+		return time.FixedZone(name, offset*60*60)
+	}
+	// The military zones are treated as UTC, as specified in RFC 5322.
+	if len(name) == 1 && name != "J" {
+		return time.UTC
+	}
+	return nil
+}
+
+// httpDate parses the HTTP-date of RFC 7231, which is the IMF-fixdate,
+// or the obsolete RFC 850 or asctime format.
+func (p *presetParser) httpDate() (time.Time, bool) {
+	var year, month, day, hour, minute, second int
+	if p.name(longWeekNames); !p.failed {
+		// Sunday, 06-Nov-94 08:49:37 GMT
+		p.expect(',')
+		p.expect(' ')
+		day = p.int(2, 2, 1, 31)
+		p.expect('-')
+		month = p.name(shortMonthNames)
+		p.expect('-')
+		if year = p.int(2, 2, 0, 99); year < 50 {
+			year += 2000
+		} else {
+			year += 1900
+		}
+	} else if p.failed = false; p.name(shortWeekNames) > 0 && p.accept(' ') {
+		// Sun Nov  6 08:49:37 1994
+		month, day, hour, minute, second = p.syslog()
+		p.expect(' ')
+		year = p.int(4, 4, 0, 9999)
+		if p.failed || p.i != len(p.source) {
+			return time.Time{}, false
+		}
+		return time.Date(year, time.Month(month), day, hour, minute, second, 0, time.UTC), true
+	} else {
+		// Sun, 06 Nov 1994 08:49:37 GMT
+		p.expect(',')
+		p.expect(' ')
+		day = p.int(2, 2, 1, 31)
+		p.expect(' ')
+		month = p.name(shortMonthNames)
+		p.expect(' ')
+		year = p.int(4, 4, 0, 9999)
+	}
+	p.expect(' ')
+	hour, minute, second = p.clock(':')
+	if p.failed || p.source[p.i:] != " GMT" {
+		return time.Time{}, false
+	}
+	return time.Date(year, time.Month(month), day, hour, minute, second, 0, time.UTC), true
+}
@@ -0,0 +1,436 @@
+package timefmt_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+)
+
+var (
+	presetTestLocations = []*time.Location{
+		time.UTC,
+		time.FixedZone("JST", 9*60*60),
+		time.FixedZone("", -(3*60+30)*60),
+		time.FixedZone("", 5*60*60+45*60+30),
+	}
+	presetTestTimes = []time.Time{
+		time.Date(2020, time.July, 4, 9, 7, 29, 123456789, time.UTC),
+		time.Date(1999, time.December, 31, 23, 59, 59, 0, time.UTC),
+		time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(9999, time.December, 31, 12, 0, 0, 1000, time.UTC),
+		time.Date(10000, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(-1, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+)
+
+func TestFormatPresets(t *testing.T) {
+	presets := []struct {
+		format, generic string
+	}{
+		{timefmt.RFC3339, "%FT%T%:z"},
+		{timefmt.RFC2822, "%a, %d %b %Y %T %z"},
+		{timefmt.HTTPDate, "%a, %d %b %Y %T GMT"},
+		{timefmt.RFC3164, "%b %e %T"},
+		{timefmt.RFC5424, "%FT%T.%f%:z"},
+		{timefmt.CLF, "%d/%b/%Y:%T %z"},
+		{timefmt.ISO8601Basic, "%Y%m%dT%H%M%2S%z"},
+		{timefmt.ASCTime, "%c"},
+	}
+	for _, preset := range presets {
+		for _, loc := range presetTestLocations {
+			for _, tm := range presetTestTimes {
+				tm := tm.In(loc)
+				got := timefmt.Format(tm, preset.format)
+				if expected := timefmt.Format(tm, preset.generic); got != expected {
+					t.Errorf("%q: expected: %q, got: %q", preset.format, expected, got)
+				}
+			}
+		}
+	}
+}
+
+func TestFormatHTTPDate(t *testing.T) {
+	tm := time.Date(2020, time.July, 24, 18, 7, 29, 0, time.FixedZone("JST", 9*60*60))
+	if got, expected := timefmt.FormatHTTPDate(tm), "Fri, 24 Jul 2020 09:07:29 GMT"; got != expected {
+		t.Errorf("expected: %q, got: %q", expected, got)
+	}
+	if got, expected := timefmt.Format(tm, timefmt.HTTPDate), "Fri, 24 Jul 2020 18:07:29 GMT"; got != expected {
+		t.Errorf("expected: %q, got: %q", expected, got)
+	}
+}
+
+func TestParsePresetsGeneric(t *testing.T) {
+	presets := []struct {
+		format, generic string
+	}{
+		{timefmt.RFC3339, "%FT%T%:z"},
+		{timefmt.RFC2822, "%a, %d %b %Y %T %z"},
+		{timefmt.HTTPDate, "%a, %d %b %Y %T GMT"},
+		{timefmt.RFC3164, "%b %e %T"},
+		{timefmt.RFC5424, "%FT%T.%f%:z"},
+		{timefmt.CLF, "%d/%b/%Y:%T %z"},
+		{timefmt.ISO8601Basic, "%C%y%m%dT%H%M%S%z"},
+		{timefmt.ASCTime, "%a %b %e %T %Y"},
+	}
+	for _, preset := range presets {
+		for _, loc := range presetTestLocations {
+			for _, tm := range presetTestTimes {
+				if year := tm.Year(); year < 0 || 9999 < year {
+					continue
+				}
+				source := timefmt.Format(tm.In(loc), preset.format)
+				for _, source := range []string{source, strings.ToLower(source), source + " "} {
+					got, err := timefmt.ParseInLocation(source, preset.format, loc)
+					expected, expectedErr := timefmt.ParseInLocation(source, preset.generic, loc)
+					if (err == nil) != (expectedErr == nil) {
+						t.Errorf("%q with %q: expected error %v, got: %v", source, preset.format, expectedErr, err)
+						continue
+					}
+					gotName, gotOffset := got.Zone()
+					name, offset := expected.Zone()
+					if !got.Equal(expected) || name != gotName || offset != gotOffset {
+						t.Errorf("%q with %q: expected: %v, got: %v", source, preset.format, expected, got)
+					}
+				}
+			}
+		}
+	}
+}
+
+var parsePresetTestCases = []struct {
+	source   string
+	format   string
+	parse    func(string) (time.Time, error)
+	t        time.Time
+	parseErr error
+}{
+	{
+		source: "2020-07-24T09:07:29+09:00",
+		format: timefmt.RFC3339,
+		t:      time.Date(2020, time.July, 24, 9, 7, 29, 0, time.FixedZone("", 9*60*60)),
+	},
+	{
+		source: "2020-07-24T09:07:29+09:00",
+		format: timefmt.RFC3339,
+		parse:  timefmt.ParseRFC3339,
+		t:      time.Date(2020, time.July, 24, 9, 7, 29, 0, time.FixedZone("", 9*60*60)),
+	},
+	{
+		source: "2020-07-24t09:07:29.123456789z",
+		format: timefmt.RFC3339,
+		parse:  timefmt.ParseRFC3339,
+		t:      time.Date(2020, time.July, 24, 9, 7, 29, 123456789, time.UTC),
+	},
+	{
+		source:   "2020-07-24t09:07:29.123456789z",
+		format:   timefmt.RFC3339,
+		parseErr: errors.New(`expected 'T'`),
+	},
+	{
+		source: "2020-07-24 09:07:29Z",
+		format: timefmt.RFC3339,
+		parse:  timefmt.ParseRFC3339,
+		t:      time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC),
+	},
+	{
+		source:   "2020-07-24 09:07:29.5+09:00",
+		format:   timefmt.RFC3339,
+		parseErr: errors.New(`expected 'T'`),
+	},
+	{
+		source:   "2020-07-24T09:07:29.5+09:00",
+		format:   timefmt.RFC3339,
+		parseErr: errors.New(`cannot parse "%:z"`),
+	},
+	{
+		source: "20-7-24T9:07:29+00:00",
+		format: timefmt.RFC3339,
+		t:      time.Date(20, time.July, 24, 9, 7, 29, 0, time.FixedZone("", 0)),
+	},
+	{
+		source: "20-7-24T9:07:29+00:00",
+		format: timefmt.RFC3339,
+		parse:  timefmt.ParseRFC3339,
+		t:      time.Date(20, time.July, 24, 9, 7, 29, 0, time.FixedZone("", 0)),
+	},
+	{
+		source:   "2020-07-24T09:07:29",
+		format:   timefmt.RFC3339,
+		parse:    timefmt.ParseRFC3339,
+		parseErr: errors.New(`cannot parse "%:z"`),
+	},
+	{
+		source: "Fri, 24 Jul 2020 09:07:29 +0900",
+		format: timefmt.RFC2822,
+		t:      time.Date(2020, time.July, 24, 9, 7, 29, 0, time.FixedZone("", 9*60*60)),
+	},
+	{
+		source: "24 Jul 20 09:07 EDT",
+		format: timefmt.RFC2822,
+		parse:  timefmt.ParseRFC2822,
+		t:      time.Date(2020, time.July, 24, 9, 7, 0, 0, time.FixedZone("EDT", -4*60*60)),
+	},
+	{
+		source: "24 Jul 20 09:07 est",
+		format: timefmt.RFC2822,
+		parse:  timefmt.ParseRFC2822,
+		t:      time.Date(2020, time.July, 24, 9, 7, 0, 0, time.FixedZone("EST", -5*60*60)),
+	},
+	{
+		source: "24 Jul 20 09:07 ut",
+		format: timefmt.RFC2822,
+		parse:  timefmt.ParseRFC2822,
+		t:      time.Date(2020, time.July, 24, 9, 7, 0, 0, time.UTC),
+	},
+	{
+		source: "24 Jul 20 09:07 z",
+		format: timefmt.RFC2822,
+		parse:  timefmt.ParseRFC2822,
+		t:      time.Date(2020, time.July, 24, 9, 7, 0, 0, time.UTC),
+	},
+	{
+		source:   "Fri, 24 Jul 2020 09:07:29 EST",
+		format:   timefmt.RFC2822,
+		parseErr: errors.New(`cannot parse "%z"`),
+	},
+	{
+		source: "Fri ,  4 Jul 99 09:07:29 GMT (comment)",
+		format: timefmt.RFC5322,
+		parse:  timefmt.ParseRFC2822,
+		t:      time.Date(1999, time.July, 4, 9, 7, 29, 0, time.UTC),
+	},
+	{
+		source: "4 Jul 120 09:07:29 A",
+		format: timefmt.RFC2822,
+		parse:  timefmt.ParseRFC2822,
+		t:      time.Date(2020, time.July, 4, 9, 7, 29, 0, time.UTC),
+	},
+	{
+		source:   "Fri, 24 Jul 2020 09:07:29 A",
+		format:   timefmt.RFC2822,
+		parseErr: errors.New(`cannot parse "%z"`),
+	},
+	{
+		source:   "Fri, 24 Jul 2020 09:07:29 JST",
+		format:   timefmt.RFC2822,
+		parse:    timefmt.ParseRFC2822,
+		parseErr: errors.New(`cannot parse "%z"`),
+	},
+	{
+		source: "Fri, 24 Jul 2020 09:07:29 GMT",
+		format: timefmt.HTTPDate,
+		t:      time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC),
+	},
+	{
+		source: "Fri, 24 Jul 2020 09:07:29 GMT",
+		format: timefmt.HTTPDate,
+		parse:  timefmt.ParseHTTPDate,
+		t:      time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC),
+	},
+	{
+		source: "Friday, 24-Jul-20 09:07:29 GMT",
+		format: timefmt.HTTPDate,
+		parse:  timefmt.ParseHTTPDate,
+		t:      time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC),
+	},
+	{
+		source:   "Friday, 24-Jul-20 09:07:29 GMT",
+		format:   timefmt.HTTPDate,
+		parseErr: errors.New(`expected ','`),
+	},
+	{
+		source: "Fri Jul  4 09:07:29 2020",
+		format: timefmt.HTTPDate,
+		parse:  timefmt.ParseHTTPDate,
+		t:      time.Date(2020, time.July, 4, 9, 7, 29, 0, time.UTC),
+	},
+	{
+		source:   "Fri, 24 Jul 2020 09:07:29 UTC",
+		format:   timefmt.HTTPDate,
+		parse:    timefmt.ParseHTTPDate,
+		parseErr: errors.New(`expected 'G'`),
+	},
+	{
+		source: "Jul  4 09:07:29",
+		format: timefmt.RFC3164,
+		t:      time.Date(1900, time.July, 4, 9, 7, 29, 0, time.UTC),
+	},
+	{
+		source: "2020-07-24T09:07:29.5-07:00",
+		format: timefmt.RFC5424,
+		t:      time.Date(2020, time.July, 24, 9, 7, 29, 500000000, time.FixedZone("", -7*60*60)),
+	},
+	{
+		source:   "2020-07-24T09:07:29Z",
+		format:   timefmt.RFC5424,
+		parseErr: errors.New(`expected '.'`),
+	},
+	{
+		source: "24/Jul/2020:09:07:29 -0700",
+		format: timefmt.CLF,
+		t:      time.Date(2020, time.July, 24, 9, 7, 29, 0, time.FixedZone("", -7*60*60)),
+	},
+	{
+		source:   "24/Jul/2020:09:07:29",
+		format:   timefmt.CLF,
+		parseErr: errors.New(`expected ' '`),
+	},
+	{
+		source: "20200724T090729Z",
+		format: timefmt.ISO8601Basic,
+		t:      time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC),
+	},
+	{
+		source: "20200724T090729+09",
+		format: timefmt.ISO8601Basic,
+		t:      time.Date(2020, time.July, 24, 9, 7, 29, 0, time.FixedZone("", 9*60*60)),
+	},
+	{
+		source: "2020-07-24T09:07:29+09:00",
+		format: timefmt.ISO8601Extended,
+		t:      time.Date(2020, time.July, 24, 9, 7, 29, 0, time.FixedZone("", 9*60*60)),
+	},
+	{
+		source: "Fri Jul 24 09:07:29 2020",
+		format: timefmt.ASCTime,
+		t:      time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC),
+	},
+}
+
+func TestParsePresets(t *testing.T) {
+	for _, tc := range parsePresetTestCases {
+		t.Run(tc.source+"/"+tc.format, func(t *testing.T) {
+			parse := tc.parse
+			if parse == nil {
+				parse = func(source string) (time.Time, error) {
+					return timefmt.Parse(source, tc.format)
+				}
+			}
+			got, err := parse(tc.source)
+			if tc.parseErr == nil {
+				if err != nil {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				if !got.Equal(tc.t) {
+					t.Errorf("expected: %v, got: %v", tc.t, got)
+				}
+				name, offset := tc.t.Zone()
+				gotName, gotOffset := got.Zone()
+				if name != gotName || offset != gotOffset {
+					t.Errorf("expected zone: name = %s, offset = %d, got zone: name = %s, offset = %d",
+						name, offset,
+						gotName, gotOffset,
+					)
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("expected error %v but got: %v", tc.parseErr, err)
+				}
+				if !strings.Contains(err.Error(), tc.parseErr.Error()) {
+					t.Errorf("expected: %v, got: %v", tc.parseErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestParsePresetsInLocation(t *testing.T) {
+	loc := time.FixedZone("JST", 9*60*60)
+	for _, format := range []string{timefmt.RFC3164, timefmt.ASCTime} {
+		source := timefmt.Format(benchTime.In(loc), format)
+		got, err := timefmt.ParseInLocation(source, format, loc)
+		if err != nil {
+			t.Fatalf("expected no error but got: %v", err)
+		}
+		if timefmt.Format(got, format) != source || got.Location() != loc {
+			t.Errorf("expected: %v, got: %v", source, got)
+		}
+	}
+}
+
+func BenchmarkFormatRFC3339(b *testing.B) {
+	for b.Loop() {
+		timefmt.Format(benchTime, timefmt.RFC3339)
+	}
+}
+
+func BenchmarkFormatRFC3339Generic(b *testing.B) {
+	for b.Loop() {
+		timefmt.Format(benchTime, "%FT%T%:z")
+	}
+}
+
+func BenchmarkFormatRFC2822(b *testing.B) {
+	for b.Loop() {
+		timefmt.Format(benchTime, timefmt.RFC2822)
+	}
+}
+
+func BenchmarkFormatRFC2822Generic(b *testing.B) {
+	for b.Loop() {
+		timefmt.Format(benchTime, "%a, %d %b %Y %T %z")
+	}
+}
+
+func BenchmarkFormatCLF(b *testing.B) {
+	for b.Loop() {
+		timefmt.Format(benchTime, timefmt.CLF)
+	}
+}
+
+func BenchmarkFormatCLFGeneric(b *testing.B) {
+	for b.Loop() {
+		timefmt.Format(benchTime, "%d/%b/%Y:%T %z")
+	}
+}
+
+func BenchmarkParseRFC3339(b *testing.B) {
+	for b.Loop() {
+		_, _ = timefmt.Parse("2020-09-08T07:06:05+09:00", timefmt.RFC3339)
+	}
+}
+
+func BenchmarkParseRFC3339Generic(b *testing.B) {
+	for b.Loop() {
+		_, _ = timefmt.Parse("2020-09-08T07:06:05+09:00", "%FT%T%:z")
+	}
+}
+
+func BenchmarkParseRFC3339Lenient(b *testing.B) {
+	for b.Loop() {
+		_, _ = timefmt.ParseRFC3339("2020-09-08 07:06:05.123+09:00")
+	}
+}
+
+func BenchmarkParseRFC2822(b *testing.B) {
+	for b.Loop() {
+		_, _ = timefmt.Parse("Tue, 08 Sep 2020 07:06:05 +0900", timefmt.RFC2822)
+	}
+}
+
+func BenchmarkParseRFC2822Generic(b *testing.B) {
+	for b.Loop() {
+		_, _ = timefmt.Parse("Tue, 08 Sep 2020 07:06:05 +0900", "%a, %d %b %Y %T %z")
+	}
+}
+
+func BenchmarkParseRFC2822Lenient(b *testing.B) {
+	for b.Loop() {
+		_, _ = timefmt.ParseRFC2822("8 Sep 20 07:06 EDT")
+	}
+}
+
+func BenchmarkParseCLF(b *testing.B) {
+	for b.Loop() {
+		_, _ = timefmt.Parse("08/Sep/2020:07:06:05 +0900", timefmt.CLF)
+	}
+}
+
+func BenchmarkParseCLFGeneric(b *testing.B) {
+	for b.Loop() {
+		_, _ = timefmt.Parse("08/Sep/2020:07:06:05 +0900", "%d/%b/%Y:%T %z")
+	}
+}
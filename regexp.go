@@ -0,0 +1,263 @@
+package timefmt
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Regexp returns a regular expression of RE2 syntax, which matches the strings
+// formatted by the format. Each directive is captured by a named group like
+// year, month and day, suffixed by a number from the second occurrence. It
+// respects the width and the flags of the directives, and the colons of %z.
+// The years are assumed to be from 0 to 9999, and the widths of the invalid
+// directives are not respected.
+func Regexp(format string) (string, error) {
+	return formatRegexp(format, nil)
+}
+
+func formatRegexp(format string, lc *Locale) (string, error) {
+	var sb strings.Builder
+	counts := map[string]int{}
+	tokens := scanFormat(format)
+	for i, token := range tokens {
+		if token.Directive == 0 {
+			sb.WriteString(regexp.QuoteMeta(token.Literal))
+			continue
+		}
+		name, pattern, err := directivePattern(token, lc)
+		if err != nil {
+			return "", err
+		}
+		if token.Directive == 'f' && token.Padding == 0 && token.Width == 0 &&
+			(i+1 == len(tokens) || !tokens[i+1].isDigits()) {
+			// the parser accepts nanoseconds unless followed by a directive of digits
+			pattern += "(?:[0-9]{3})?"
+		}
+		if name == "" {
+			sb.WriteString(pattern)
+			continue
+		}
+		if counts[name]++; counts[name] > 1 {
+			name += strconv.Itoa(counts[name])
+		}
+		sb.WriteString("(?P<" + name + ">" + pattern + ")")
+	}
+	return sb.String(), nil
+}
+
+// directivePattern returns the group name and the pattern of the directive.
+// The patterns of the numeric directives are derived from the ranges of the
+// values in the directive table.
+func directivePattern(token Token, lc *Locale) (string, string, error) {
+	info, ok := lookupDirective(token.Modifier, token.Directive)
+	if !ok || !info.Parse {
+		return "", "", fmt.Errorf("unsupported directive %q", token.Literal)
+	}
+	padding := token.paddingByte()
+	if spacePadded(token.Directive) {
+		padding = spacePadding(padding)
+	}
+	switch token.Directive {
+	case 'B':
+		return "month", namesPattern(longMonthNames, token), nil
+	case 'b', 'h':
+		return "month", namesPattern(shortMonthNames, token), nil
+	case 'A':
+		return "weekday", namesPattern(longWeekNames, token), nil
+	case 'a':
+		return "weekday", namesPattern(shortWeekNames, token), nil
+	case 'o':
+		suffixes := make([]string, 0, 4)
+		for day := info.Min; day <= info.Max; day++ {
+			if suffix := lc.ordinalSuffix(day); !slices.Contains(suffixes, suffix) {
+				suffixes = append(suffixes, suffix)
+			}
+		}
+		pattern := numberPattern(info.Min, info.Max, token.Width, padding)
+		token.Width = 0
+		return "day", "(?:" + pattern + ")(?:" + namesPattern(suffixes, token) + ")", nil
+	case 'P':
+		token.Swap = !token.Upper && !token.Swap
+		fallthrough
+	case 'p':
+		return "ampm", namesPattern([]string{"AM", "PM"}, token), nil
+	case 's':
+		if padding == ^paddingMask || token.Width <= 1 {
+			return "unix", "-?[0-9]+", nil
+		}
+		return "unix", regexp.QuoteMeta(string(padding&paddingMask)) + "*-?[0-9]+", nil
+	case 'z':
+		return "offset", offsetPattern(token.Colons, token.Width, padding), nil
+	case 'Z':
+		name := "[A-Za-z]+|[+-][0-9]{2}(?:[0-9]{2})?"
+		if padding != ^paddingMask && token.Width > 1 {
+			name = regexp.QuoteMeta(string(spacePadding(padding)&paddingMask)) + "*(?:" + name + ")"
+		}
+		return "zone", name + "|" + offsetPattern(0, token.Width, padding), nil
+	case 't', 'n', '%':
+		if token.Width == 0 {
+			return "", `\` + string(token.Directive), nil
+		}
+		token.Upper, token.Swap = false, false
+		return "", "(?:" + namesPattern([]string{info.Placeholder}, token) + ")", nil
+	default:
+		return groupNames[token.Directive], numberPattern(max(info.Min, 0), info.Max, token.numberWidth(info), padding), nil
+	}
+}
+
+// groupNames is the table of the group names of the numeric directives.
+var groupNames = [256]string{
+	'Y': "year", 'y': "year", 'C': "century", 'G': "isoyear", 'g': "isoyear",
+	'm': "month", 'q': "quarter", 'Q': "half", 'w': "weekday", 'u': "weekday",
+	'V': "week", 'U': "week", 'W': "week", 'e': "day", 'd': "day", 'j': "yday",
+	'k': "hour", 'H': "hour", 'l': "hour", 'I': "hour", 'M': "minute",
+	'S': "second", 'f': "fraction",
+}
+
+// offsetPattern returns the pattern of the time zone offset with the colons,
+// padded to the width as the formatter does.
+func offsetPattern(colons, width int, padding byte) string {
+	var pattern string
+	switch {
+	case padding == ^paddingMask:
+		pattern = "[+-][0-9]{1,2}"
+	case padding&paddingMask == '0':
+		pattern = "[+-]"
+		if width > 1 {
+			pattern += "0*"
+		}
+		pattern += "[0-9]{2}"
+	default:
+		pattern = regexp.QuoteMeta(string(padding&paddingMask)) + "*[+-][0-9]{1,2}"
+	}
+	minute := "[0-5][0-9]"
+	switch colons {
+	case 0:
+		return pattern + minute
+	case 1:
+		return pattern + ":" + minute
+	case 2:
+		return pattern + ":" + minute + ":" + minute
+	default:
+		return pattern + "(?::" + minute + "(?::" + minute + ")?)?"
+	}
+}
+
+// spacePadding returns the padding of the directives padded with spaces by
+// default.
+func spacePadding(padding byte) byte {
+	if padding < ^paddingMask {
+		return ' '
+	}
+	return padding
+}
+
+// numberPattern returns the pattern of the integers from minimum to maximum,
+// padded to the width as appendInt does.
+func numberPattern(minimum, maximum, width int, padding byte) string {
+	if padding != ^paddingMask {
+		padding &= paddingMask
+	}
+	digits := len(strconv.Itoa(maximum))
+	var patterns []string
+	if padding == '0' && width >= digits {
+		prefix := strings.Repeat("0", width-digits)
+		for _, pattern := range fixedRangePattern(
+			fmt.Sprintf("%0*d", digits, minimum), strconv.Itoa(maximum)) {
+			patterns = append(patterns, prefix+pattern)
+		}
+		return strings.Join(patterns, "|")
+	}
+	for n := digits; n >= len(strconv.Itoa(minimum)); n-- {
+		lower, upper := minimum, maximum
+		if n > 1 {
+			lower = max(lower, pow10(n-1))
+		}
+		upper = min(upper, pow10(n)-1)
+		var prefix string
+		if padding != ^paddingMask && width > n {
+			prefix = regexp.QuoteMeta(strings.Repeat(string(padding), width-n))
+		}
+		for _, pattern := range fixedRangePattern(strconv.Itoa(lower), strconv.Itoa(upper)) {
+			patterns = append(patterns, prefix+pattern)
+		}
+	}
+	return strings.Join(patterns, "|")
+}
+
+func pow10(n int) int {
+	x := 1
+	for range n {
+		x *= 10
+	}
+	return x
+}
+
+// fixedRangePattern returns the alternatives of the pattern of the integers
+// from lower to upper, which have the same number of digits.
+func fixedRangePattern(lower, upper string) []string {
+	if lower == upper {
+		return []string{lower}
+	}
+	if len(lower) == 1 {
+		return []string{digitClass(lower[0], upper[0])}
+	}
+	if lower[0] == upper[0] {
+		patterns := fixedRangePattern(lower[1:], upper[1:])
+		if len(patterns) == 1 {
+			return []string{lower[:1] + patterns[0]}
+		}
+		return []string{lower[:1] + "(?:" + strings.Join(patterns, "|") + ")"}
+	}
+	n := len(lower) - 1
+	zeros, nines := strings.Repeat("0", n), strings.Repeat("9", n)
+	first, last := lower[0], upper[0]
+	var patterns, tail []string
+	if lower[1:] != zeros {
+		for _, pattern := range fixedRangePattern(lower[1:], nines) {
+			patterns = append(patterns, lower[:1]+pattern)
+		}
+		first++
+	}
+	if upper[1:] != nines {
+		for _, pattern := range fixedRangePattern(zeros, upper[1:]) {
+			tail = append(tail, upper[:1]+pattern)
+		}
+		last--
+	}
+	if first == '0' && last == '9' {
+		patterns = append(patterns, "[0-9]{"+strconv.Itoa(n+1)+"}")
+	} else if first <= last {
+		pattern := digitClass(first, last) + "[0-9]"
+		if n > 1 {
+			pattern += "{" + strconv.Itoa(n) + "}"
+		}
+		patterns = append(patterns, pattern)
+	}
+	return append(patterns, tail...)
+}
+
+func digitClass(first, last byte) string {
+	switch {
+	case first == last:
+		return string(first)
+	case first+1 == last:
+		return "[" + string(first) + string(last) + "]"
+	default:
+		return "[" + string(first) + "-" + string(last) + "]"
+	}
+}
+
+// namesPattern returns the pattern of the names formatted as appendString does.
+func namesPattern(names []string, token Token) string {
+	patterns := make([]string, len(names))
+	for i, name := range names {
+		patterns[i] = regexp.QuoteMeta(string(appendString(nil, name,
+			token.Width, token.paddingByte(), token.Upper, token.Swap)))
+	}
+	slices.SortStableFunc(patterns, func(x, y string) int { return len(y) - len(x) })
+	return strings.Join(patterns, "|")
+}
@@ -0,0 +1,191 @@
+package timefmt_test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+)
+
+var regexpTestCases = []struct {
+	format   string
+	expected string
+}{
+	{
+		format:   "%Y-%m-%d",
+		expected: `(?P<year>[0-9]{4})-(?P<month>0[1-9]|1[0-2])-(?P<day>0[1-9]|[12][0-9]|3[01])`,
+	},
+	{
+		format:   "%H:%M:%S.%f",
+		expected: `(?P<hour>[01][0-9]|2[0-3]):(?P<minute>[0-5][0-9]):(?P<second>[0-5][0-9]|60)\.(?P<fraction>[0-9]{6}(?:[0-9]{3})?)`,
+	},
+	{
+		format:   "%-m/%e %k",
+		expected: `(?P<month>1[0-2]|[1-9])/(?P<day>[12][0-9]|3[01]| [1-9]) (?P<hour>1[0-9]|2[0-3]| [0-9])`,
+	},
+	{
+		format:   "%_3j %03e",
+		expected: `(?P<yday>[12][0-9]{2}|3[0-5][0-9]|36[0-6]| [1-9][0-9]|  [1-9]) (?P<day>00[1-9]|0[12][0-9]|03[01])`,
+	},
+	{
+		format:   "%a %^b",
+		expected: `(?P<weekday>Sun|Mon|Tue|Wed|Thu|Fri|Sat) (?P<month>JAN|FEB|MAR|APR|MAY|JUN|JUL|AUG|SEP|OCT|NOV|DEC)`,
+	},
+	{
+		format:   "%I %p %P",
+		expected: `(?P<hour>0[1-9]|1[0-2]) (?P<ampm>AM|PM) (?P<ampm2>am|pm)`,
+	},
+	{
+		format:   "%z %:z %::z %:::z",
+		expected: `(?P<offset>[+-][0-9]{2}[0-5][0-9]) (?P<offset2>[+-][0-9]{2}:[0-5][0-9]) (?P<offset3>[+-][0-9]{2}:[0-5][0-9]:[0-5][0-9]) (?P<offset4>[+-][0-9]{2}(?::[0-5][0-9](?::[0-5][0-9])?)?)`,
+	},
+	{
+		format:   "[%s]%t%%",
+		expected: `\[(?P<unix>-?[0-9]+)\]\t%`,
+	},
+}
+
+func TestRegexp(t *testing.T) {
+	for _, tc := range regexpTestCases {
+		t.Run(tc.format, func(t *testing.T) {
+			got, err := timefmt.Regexp(tc.format)
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected: %q, got: %q", tc.expected, got)
+			}
+			if _, err := regexp.Compile(got); err != nil {
+				t.Errorf("expected no error but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestRegexpFormat(t *testing.T) {
+	for _, tc := range formatTestCases {
+		if tc.t.Year() < 0 || tc.t.Year() > 9999 || strings.Contains(tc.expected, "%") {
+			// the widths of the invalid directives are not respected
+			continue
+		}
+		pattern, err := timefmt.Regexp(tc.format)
+		if err != nil {
+			continue
+		}
+		if !regexp.MustCompile(`^(?:` + pattern + `)$`).MatchString(tc.expected) {
+			t.Errorf("expected %q to match %q with %q", tc.expected, pattern, tc.format)
+		}
+	}
+}
+
+func TestRegexpParse(t *testing.T) {
+	formats := []string{
+		"%Y-%m-%d %H:%M:%S.%f %z",
+		"%a, %d %b %Y %T %:z",
+		"%A %B %d %I:%M %p",
+		"%c",
+		"%y%m%d %H%M",
+		"%G-W%V-%u",
+		"%j %l %P",
+		"%s",
+	}
+	loc := time.FixedZone("", -(3*60+30)*60)
+	for _, format := range formats {
+		t.Run(format, func(t *testing.T) {
+			pattern, err := timefmt.Regexp(format)
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+			r := regexp.MustCompile(`^(?:` + pattern + `)$`)
+			for tm := time.Date(1999, time.December, 31, 7, 8, 9, 123456000, loc); tm.Year() < 2002; tm = tm.Add(37*time.Hour + 11*time.Minute) {
+				s := timefmt.Format(tm, format)
+				if !r.MatchString(s) {
+					t.Fatalf("expected %q to match %q", s, pattern)
+				}
+				if _, err := timefmt.Parse(s, format); err != nil {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestRegexpPercent(t *testing.T) {
+	tm := time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC)
+	for _, format := range []string{"S n%7%", "%_3%%%", "%-5%|%05%"} {
+		pattern, err := timefmt.Regexp(format)
+		if err != nil {
+			t.Fatalf("expected no error but got: %v", err)
+		}
+		if s := timefmt.Format(tm, format); !regexp.MustCompile(`^(?:` + pattern + `)$`).MatchString(s) {
+			t.Errorf("expected %q to match %q with %q", s, pattern, format)
+		}
+	}
+}
+
+func TestRegexpNanoseconds(t *testing.T) {
+	for _, tc := range []struct {
+		source, format string
+		match          bool
+	}{
+		{"29.123456789", "%S.%f", true},
+		{"29.123456789+0900", "%S.%f%z", true},
+		{"29.123456", "%S.%f", true},
+		{"29.12345678924", "%S.%f%d", false},
+		{"29.12345624", "%S.%f%d", true},
+	} {
+		pattern, err := timefmt.Regexp(tc.format)
+		if err != nil {
+			t.Fatalf("expected no error but got: %v", err)
+		}
+		if got := regexp.MustCompile(`^(?:` + pattern + `)$`).MatchString(tc.source); got != tc.match {
+			t.Errorf("expected %q with %q to match: %v, got: %v", tc.source, tc.format, tc.match, got)
+		}
+		if _, err := timefmt.Parse(tc.source, tc.format); (err == nil) != tc.match {
+			t.Errorf("expected %q with %q to parse: %v, got: %v", tc.source, tc.format, tc.match, err)
+		}
+	}
+}
+
+func TestRegexpReject(t *testing.T) {
+	for _, tc := range []struct {
+		source, format string
+	}{
+		{"2020-13-01", "%Y-%m-%d"},
+		{"2020-07-32", "%Y-%m-%d"},
+		{"24:00", "%H:%M"},
+		{"12:60", "%H:%M"},
+		{"0 AM", "%I %p"},
+		{"Fry Jul", "%a %b"},
+	} {
+		pattern, err := timefmt.Regexp(tc.format)
+		if err != nil {
+			t.Fatalf("expected no error but got: %v", err)
+		}
+		if regexp.MustCompile(`^(?:` + pattern + `)$`).MatchString(tc.source) {
+			t.Errorf("expected %q not to match %q", tc.source, pattern)
+		}
+		if _, err := timefmt.Parse(tc.source, tc.format); err == nil {
+			t.Errorf("expected an error on parsing %q with %q", tc.source, tc.format)
+		}
+	}
+}
+
+func TestRegexpError(t *testing.T) {
+	_, err := timefmt.Regexp("%Y %E")
+	if expected := `unsupported directive "%E"`; err == nil || err.Error() != expected {
+		t.Errorf("expected error %q but got: %v", expected, err)
+	}
+}
+
+func ExampleRegexp() {
+	pattern, _ := timefmt.Regexp("%Y-%m-%d")
+	r := regexp.MustCompile(pattern)
+	m := r.FindStringSubmatch("released on 2020-07-24")
+	fmt.Println(m[r.SubexpIndex("year")], m[r.SubexpIndex("month")], m[r.SubexpIndex("day")])
+	// Output:
+	// 2020 07 24
+}
@@ -0,0 +1,348 @@
+// Package relative provides a parser of relative date time expressions like
+// "tomorrow 9:00", "next friday", "3 days ago" and "2020-07-24 +2 weeks".
+//
+// An expression is a sequence of the following items separated by spaces,
+// which are applied to the reference time from left to right.
+//
+//	item      = absolute | keyword | offset | relative | weekday | boundary
+//	absolute  = date or time string parsed by the date or time formats
+//	keyword   = "now" | "today" | "tomorrow" | "yesterday" | "noon" | "midnight"
+//	offset    = ["in"] ["+" | "-"] amount unit ["ago"]
+//	amount    = number | "a" | "an"
+//	relative  = ("next" | "last" | "this") (unit | weekday)
+//	weekday   = "sunday" | "sun" | "monday" | "mon" | ... | "saturday" | "sat"
+//	boundary  = ("first" | "last") "day" "of" ["next" | "last" | "this"] ("week" | "month" | "year")
+//	unit      = "second" | "sec" | "minute" | "min" | "hour" | "day" | "week"
+//	          | "fortnight" | "month" | "year" (with optional plural "s")
+//
+// A date string sets the date and time of the parsed string, and a time string
+// sets the time of the day. The keywords today, tomorrow and yesterday keep the
+// time of the day, while noon and midnight set it. The weekday item moves to the
+// day on or after the current date (this), after the current date (next), or
+// before the current date (last), and sets the time to midnight. The units of
+// days or longer are calendar-aware, and the others are added as elapsed time.
+// The keywords and units are case-insensitive.
+package relative
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+)
+
+// DefaultDateFormats is the default formats of date strings.
+var DefaultDateFormats = []string{
+	timefmt.RFC3339,
+	"%Y-%m-%dT%H:%M:%S",
+	"%Y-%m-%d %H:%M:%S",
+	"%Y-%m-%d %H:%M",
+	"%Y-%m-%d",
+	"%Y/%m/%d",
+	"%B %d %Y",
+	"%b %d %Y",
+	"%d %B %Y",
+	"%d %b %Y",
+	"%B %d",
+	"%b %d",
+}
+
+// DefaultTimeFormats is the default formats of time strings.
+var DefaultTimeFormats = []string{
+	"%H:%M:%S",
+	"%H:%M",
+	"%I:%M%p",
+	"%I:%M %p",
+	"%I%p",
+	"%I %p",
+}
+
+// Parser is a parser of relative date time expressions.
+type Parser struct {
+	// Now returns the reference time (defaults to time.Now).
+	Now func() time.Time
+	// DateFormats is the formats of date strings (defaults to DefaultDateFormats).
+	DateFormats []string
+	// TimeFormats is the formats of time strings (defaults to DefaultTimeFormats).
+	TimeFormats []string
+}
+
+// Parse parses the expression relative to the reference time.
+func Parse(source string, now time.Time) (time.Time, error) {
+	return (&Parser{Now: func() time.Time { return now }}).Parse(source)
+}
+
+// Parse parses the expression relative to the reference time.
+func (p *Parser) Parse(source string) (t time.Time, err error) {
+	if p.Now != nil {
+		t = p.Now()
+	} else {
+		t = time.Now()
+	}
+	words := strings.Fields(source)
+	if len(words) == 0 {
+		return time.Time{}, fmt.Errorf("failed to parse %q: empty expression", source)
+	}
+	for i := 0; i < len(words); {
+		var n int
+		if t, n, err = p.parseItem(t, words[i:]); err != nil {
+			return time.Time{}, fmt.Errorf("failed to parse %q: %w", source, err)
+		}
+		i += n
+	}
+	return t, nil
+}
+
+// parseItem applies the first item of the words to the time, and returns
+// the time and the number of words consumed.
+func (p *Parser) parseItem(t time.Time, words []string) (time.Time, int, error) {
+	if u, n, ok := p.parseAbsolute(t, words); ok {
+		return u, n, nil
+	}
+	word := strings.ToLower(words[0])
+	switch word {
+	case "now":
+		return t, 1, nil
+	case "today":
+		return t, 1, nil
+	case "tomorrow":
+		return t.AddDate(0, 0, 1), 1, nil
+	case "yesterday":
+		return t.AddDate(0, 0, -1), 1, nil
+	case "noon":
+		return setClock(t, 12, 0, 0), 1, nil
+	case "midnight":
+		return setClock(t, 0, 0, 0), 1, nil
+	case "next", "last", "this":
+		if len(words) < 2 {
+			return t, 0, fmt.Errorf("expected unit or weekday after %q", words[0])
+		}
+		if word == "last" && len(words) > 2 &&
+			strings.EqualFold(words[1], "day") && strings.EqualFold(words[2], "of") {
+			return parseBoundary(t, words)
+		}
+		amount := map[string]int{"next": 1, "last": -1, "this": 0}[word]
+		if weekday, ok := parseWeekday(words[1]); ok {
+			return moveToWeekday(t, weekday, amount), 2, nil
+		}
+		if unit, ok := parseUnit(words[1]); ok {
+			return addUnit(t, unit, amount), 2, nil
+		}
+		return t, 0, fmt.Errorf("expected unit or weekday after %q but got %q", words[0], words[1])
+	case "first":
+		return parseBoundary(t, words)
+	case "in":
+		if len(words) < 2 {
+			return t, 0, errors.New(`expected amount after "in"`)
+		}
+		u, n, err := p.parseItem(t, words[1:])
+		return u, n + 1, err
+	}
+	if weekday, ok := parseWeekday(word); ok {
+		return moveToWeekday(t, weekday, 0), 1, nil
+	}
+	return parseOffset(t, words)
+}
+
+// parseAbsolute parses the longest leading words by the date or time formats.
+func (p *Parser) parseAbsolute(t time.Time, words []string) (time.Time, int, bool) {
+	dateFormats, timeFormats := p.DateFormats, p.TimeFormats
+	if dateFormats == nil {
+		dateFormats = DefaultDateFormats
+	}
+	if timeFormats == nil {
+		timeFormats = DefaultTimeFormats
+	}
+	for n := len(words); n > 0; n-- {
+		source := strings.Join(words[:n], " ")
+		for _, format := range dateFormats {
+			source, format := source, format
+			if !hasYear(format) {
+				// parse in the reference year to keep the leap day
+				source, format = strconv.Itoa(t.Year())+" "+source, "%Y "+format
+			}
+			if u, err := timefmt.ParseInLocation(source, format, t.Location()); err == nil && keepsDay(source, format, u) {
+				return u, n, true
+			}
+		}
+		for _, format := range timeFormats {
+			if u, err := timefmt.ParseInLocation(source, format, t.Location()); err == nil {
+				hour, minute, second := u.Clock()
+				return setClock(t, hour, minute, second).Add(time.Duration(u.Nanosecond())), n, true
+			}
+		}
+	}
+	return t, 0, false
+}
+
+// hasYear reports whether the format has a directive of the year.
+func hasYear(format string) bool {
+	tokens, _ := timefmt.ParseFormat(format)
+	return slices.ContainsFunc(tokens, func(token timefmt.Token) bool {
+		return strings.IndexByte("YyCGgs", token.Directive) >= 0
+	})
+}
+
+// keepsDay reports whether the day of month in the source is kept in the time,
+// since the parser normalizes the invalid days like February 30.
+func keepsDay(source, format string, t time.Time) bool {
+	pattern, err := timefmt.Regexp(format)
+	if err != nil {
+		return true
+	}
+	re, err := regexp.Compile("(?i)^(?:" + pattern + ")$")
+	if err != nil {
+		return true
+	}
+	m, i := re.FindStringSubmatch(source), re.SubexpIndex("day")
+	if m == nil || i < 0 || m[i] == "" {
+		return true
+	}
+	day, err := strconv.Atoi(strings.TrimFunc(m[i], func(r rune) bool {
+		return r < '0' || '9' < r
+	}))
+	return err != nil || day == t.Day()
+}
+
+// parseOffset parses an offset like "+2 weeks" and "3 days ago".
+func parseOffset(t time.Time, words []string) (time.Time, int, error) {
+	word := strings.ToLower(words[0])
+	var amount int
+	switch word {
+	case "a", "an":
+		amount = 1
+	default:
+		var err error
+		if amount, err = strconv.Atoi(word); err != nil {
+			return t, 0, fmt.Errorf("unexpected %q", words[0])
+		}
+	}
+	if len(words) < 2 {
+		return t, 0, fmt.Errorf("expected unit after %q", words[0])
+	}
+	unit, ok := parseUnit(words[1])
+	if !ok {
+		return t, 0, fmt.Errorf("expected unit after %q but got %q", words[0], words[1])
+	}
+	n := 2
+	if len(words) > 2 && strings.EqualFold(words[2], "ago") {
+		amount, n = -amount, 3
+	}
+	return addUnit(t, unit, amount), n, nil
+}
+
+// parseBoundary parses "first day of next month" and "last day of year".
+func parseBoundary(t time.Time, words []string) (time.Time, int, error) {
+	if len(words) < 4 || !strings.EqualFold(words[1], "day") || !strings.EqualFold(words[2], "of") {
+		return t, 0, fmt.Errorf(`expected "%s day of"`, strings.ToLower(words[0]))
+	}
+	n, amount := 3, 0
+	switch strings.ToLower(words[3]) {
+	case "next":
+		n, amount = 4, 1
+	case "last":
+		n, amount = 4, -1
+	case "this":
+		n = 4
+	}
+	if len(words) <= n {
+		return t, 0, fmt.Errorf("expected unit after %q", words[n-1])
+	}
+	unit, ok := parseUnit(words[n])
+	if !ok || unit != "week" && unit != "month" && unit != "year" {
+		return t, 0, fmt.Errorf("expected week, month or year but got %q", words[n])
+	}
+	year, month, day := t.Date()
+	first := strings.EqualFold(words[0], "first")
+	switch unit {
+	case "week":
+		day += amount*7 - int(t.Weekday())
+		if !first {
+			day += 6
+		}
+	case "month":
+		// build from the first day to avoid the overflow at the end of month
+		if month, day = month+time.Month(amount), 1; !first {
+			month, day = month+1, 0
+		}
+	default:
+		if year, month, day = year+amount, time.January, 1; !first {
+			month, day = time.December, 31
+		}
+	}
+	hour, minute, second := t.Clock()
+	return time.Date(year, month, day, hour, minute, second, t.Nanosecond(), t.Location()), n + 1, nil
+}
+
+var units = map[string]string{
+	"second": "second", "sec": "second",
+	"minute": "minute", "min": "minute",
+	"hour": "hour", "day": "day", "week": "week",
+	"fortnight": "fortnight", "month": "month", "year": "year",
+}
+
+func parseUnit(word string) (string, bool) {
+	word = strings.ToLower(word)
+	if unit, ok := units[word]; ok {
+		return unit, true
+	}
+	unit, ok := units[strings.TrimSuffix(word, "s")]
+	return unit, ok
+}
+
+func addUnit(t time.Time, unit string, amount int) time.Time {
+	switch unit {
+	case "second":
+		return t.Add(time.Duration(amount) * time.Second)
+	case "minute":
+		return t.Add(time.Duration(amount) * time.Minute)
+	case "hour":
+		return t.Add(time.Duration(amount) * time.Hour)
+	case "day":
+		return t.AddDate(0, 0, amount)
+	case "week":
+		return t.AddDate(0, 0, amount*7)
+	case "fortnight":
+		return t.AddDate(0, 0, amount*14)
+	case "month":
+		return t.AddDate(0, amount, 0)
+	default:
+		return t.AddDate(amount, 0, 0)
+	}
+}
+
+func parseWeekday(word string) (time.Weekday, bool) {
+	word = strings.ToLower(word)
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if name := strings.ToLower(weekday.String()); word == name || word == name[:3] {
+			return weekday, true
+		}
+	}
+	return 0, false
+}
+
+// moveToWeekday moves the time to the weekday on or after the current date
+// (amount = 0), after the current date (amount = 1), or before the current
+// date (amount = -1), and sets the time to midnight.
+func moveToWeekday(t time.Time, weekday time.Weekday, amount int) time.Time {
+	days := (int(weekday) - int(t.Weekday()) + 7) % 7
+	switch {
+	case amount > 0 && days == 0:
+		days = 7
+	case amount < 0:
+		if days -= 7; days == 0 {
+			days = -7
+		}
+	}
+	return setClock(t.AddDate(0, 0, days), 0, 0, 0)
+}
+
+func setClock(t time.Time, hour, minute, second int) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, hour, minute, second, 0, t.Location())
+}
@@ -0,0 +1,344 @@
+package relative_test
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+	"github.com/itchyny/timefmt-go/relative"
+)
+
+// Friday, July 24, 2020
+var now = time.Date(2020, time.July, 24, 15, 4, 5, 0, time.UTC)
+
+var parseTestCases = []struct {
+	source   string
+	t        time.Time
+	parseErr error
+}{
+	{
+		source: "now",
+		t:      now,
+	},
+	{
+		source: "today",
+		t:      now,
+	},
+	{
+		source: "tomorrow",
+		t:      time.Date(2020, time.July, 25, 15, 4, 5, 0, time.UTC),
+	},
+	{
+		source: "Yesterday",
+		t:      time.Date(2020, time.July, 23, 15, 4, 5, 0, time.UTC),
+	},
+	{
+		source: "noon",
+		t:      time.Date(2020, time.July, 24, 12, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "tomorrow midnight",
+		t:      time.Date(2020, time.July, 25, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "tomorrow 9:00",
+		t:      time.Date(2020, time.July, 25, 9, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "yesterday 9:30:15",
+		t:      time.Date(2020, time.July, 23, 9, 30, 15, 0, time.UTC),
+	},
+	{
+		source: "tomorrow 9pm",
+		t:      time.Date(2020, time.July, 25, 21, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "tomorrow 9:15 am",
+		t:      time.Date(2020, time.July, 25, 9, 15, 0, 0, time.UTC),
+	},
+	{
+		source: "3 days ago",
+		t:      time.Date(2020, time.July, 21, 15, 4, 5, 0, time.UTC),
+	},
+	{
+		source: "a week ago",
+		t:      time.Date(2020, time.July, 17, 15, 4, 5, 0, time.UTC),
+	},
+	{
+		source: "in 2 hours",
+		t:      time.Date(2020, time.July, 24, 17, 4, 5, 0, time.UTC),
+	},
+	{
+		source: "+90 min",
+		t:      time.Date(2020, time.July, 24, 16, 34, 5, 0, time.UTC),
+	},
+	{
+		source: "-5 seconds",
+		t:      time.Date(2020, time.July, 24, 15, 4, 0, 0, time.UTC),
+	},
+	{
+		source: "1 fortnight",
+		t:      time.Date(2020, time.August, 7, 15, 4, 5, 0, time.UTC),
+	},
+	{
+		source: "1 year 2 months ago",
+		t:      time.Date(2021, time.May, 24, 15, 4, 5, 0, time.UTC),
+	},
+	{
+		source: "next friday",
+		t:      time.Date(2020, time.July, 31, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "last friday",
+		t:      time.Date(2020, time.July, 17, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "this friday",
+		t:      time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "friday",
+		t:      time.Date(2020, time.July, 24, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "mon 10:00",
+		t:      time.Date(2020, time.July, 27, 10, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "last thursday",
+		t:      time.Date(2020, time.July, 23, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "next week",
+		t:      time.Date(2020, time.July, 31, 15, 4, 5, 0, time.UTC),
+	},
+	{
+		source: "last month",
+		t:      time.Date(2020, time.June, 24, 15, 4, 5, 0, time.UTC),
+	},
+	{
+		source: "this year",
+		t:      now,
+	},
+	{
+		source: "last day of month",
+		t:      time.Date(2020, time.July, 31, 15, 4, 5, 0, time.UTC),
+	},
+	{
+		source: "first day of next month midnight",
+		t:      time.Date(2020, time.August, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "last day of last month",
+		t:      time.Date(2020, time.June, 30, 15, 4, 5, 0, time.UTC),
+	},
+	{
+		source: "first day of this week",
+		t:      time.Date(2020, time.July, 19, 15, 4, 5, 0, time.UTC),
+	},
+	{
+		source: "last day of year",
+		t:      time.Date(2020, time.December, 31, 15, 4, 5, 0, time.UTC),
+	},
+	{
+		source: "2020-07-24 +2 weeks",
+		t:      time.Date(2020, time.August, 7, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "2020-01-31 +1 month",
+		t:      time.Date(2020, time.March, 2, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "2020-07-24 09:30 -1 hour",
+		t:      time.Date(2020, time.July, 24, 8, 30, 0, 0, time.UTC),
+	},
+	{
+		source: "2020-07-24T09:30:00+09:00",
+		t:      time.Date(2020, time.July, 24, 9, 30, 0, 0, time.FixedZone("", 9*60*60)),
+	},
+	{
+		source: "Aug 1 noon",
+		t:      time.Date(2020, time.August, 1, 12, 0, 0, 0, time.UTC),
+	},
+	{
+		source: "1 January 2021 next monday",
+		t:      time.Date(2021, time.January, 4, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		source:   "",
+		parseErr: errors.New("empty expression"),
+	},
+	{
+		source:   "the day after tomorrow",
+		parseErr: errors.New(`unexpected "the"`),
+	},
+	{
+		source:   "3 parsecs ago",
+		parseErr: errors.New(`expected unit after "3" but got "parsecs"`),
+	},
+	{
+		source:   "3",
+		parseErr: errors.New(`expected unit after "3"`),
+	},
+	{
+		source:   "next",
+		parseErr: errors.New(`expected unit or weekday after "next"`),
+	},
+	{
+		source:   "next tuesdays",
+		parseErr: errors.New(`expected unit or weekday after "next" but got "tuesdays"`),
+	},
+	{
+		source:   "first day",
+		parseErr: errors.New(`expected "first day of"`),
+	},
+	{
+		source:   "last day of hour",
+		parseErr: errors.New(`expected week, month or year but got "hour"`),
+	},
+	{
+		source:   "last day of next",
+		parseErr: errors.New(`expected unit after "next"`),
+	},
+	{
+		source:   "in",
+		parseErr: errors.New(`expected amount after "in"`),
+	},
+}
+
+func TestParse(t *testing.T) {
+	for _, tc := range parseTestCases {
+		t.Run(tc.source, func(t *testing.T) {
+			got, err := relative.Parse(tc.source, now)
+			if tc.parseErr == nil {
+				if err != nil {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				if !got.Equal(tc.t) {
+					t.Errorf("expected: %v, got: %v", tc.t, got)
+				}
+			} else {
+				if err == nil {
+					t.Fatalf("expected error %v but got: %v", tc.parseErr, got)
+				}
+				if !strings.Contains(err.Error(), tc.parseErr.Error()) {
+					t.Errorf("expected: %v, got: %v", tc.parseErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestParseBoundaryMonthEnd(t *testing.T) {
+	now := time.Date(2020, time.January, 31, 15, 4, 5, 0, time.UTC)
+	for _, tc := range []struct {
+		source string
+		t      time.Time
+	}{
+		{"first day of next month", time.Date(2020, time.February, 1, 15, 4, 5, 0, time.UTC)},
+		{"last day of next month", time.Date(2020, time.February, 29, 15, 4, 5, 0, time.UTC)},
+		{"first day of last month", time.Date(2019, time.December, 1, 15, 4, 5, 0, time.UTC)},
+		{"last day of last month", time.Date(2019, time.December, 31, 15, 4, 5, 0, time.UTC)},
+		{"last day of next year", time.Date(2021, time.December, 31, 15, 4, 5, 0, time.UTC)},
+		{"2020-03-31 first day of last month", time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"2020-02-29 last day of next year", time.Date(2021, time.December, 31, 0, 0, 0, 0, time.UTC)},
+	} {
+		got, err := relative.Parse(tc.source, now)
+		if err != nil {
+			t.Fatalf("%s: expected no error but got: %v", tc.source, err)
+		}
+		if !got.Equal(tc.t) {
+			t.Errorf("%s: expected: %v, got: %v", tc.source, tc.t, got)
+		}
+	}
+}
+
+func TestParseLeapDay(t *testing.T) {
+	now := time.Date(2024, time.January, 31, 15, 4, 5, 0, time.UTC)
+	for _, source := range []string{"Feb 29", "February 29", "29 Feb 2024", "2024-02-29"} {
+		got, err := relative.Parse(source, now)
+		if err != nil {
+			t.Fatalf("%s: expected no error but got: %v", source, err)
+		}
+		if expected := time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC); !got.Equal(expected) {
+			t.Errorf("%s: expected: %v, got: %v", source, expected, got)
+		}
+	}
+	for _, source := range []string{"Feb 30", "April 31", "2023-02-29"} {
+		if got, err := relative.Parse(source, now); err == nil {
+			t.Errorf("%s: expected error but got: %v", source, got)
+		}
+	}
+	if _, err := relative.Parse("Feb 29", now.AddDate(-1, 0, 0)); err == nil {
+		t.Errorf("expected error but got nil")
+	}
+}
+
+func TestParserLocation(t *testing.T) {
+	loc := time.FixedZone("JST", 9*60*60)
+	p := &relative.Parser{Now: func() time.Time { return now.In(loc) }}
+	got, err := p.Parse("tomorrow 9:00")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if expected := time.Date(2020, time.July, 26, 9, 0, 0, 0, loc); !got.Equal(expected) {
+		t.Errorf("expected: %v, got: %v", expected, got)
+	}
+	if got.Location() != loc {
+		t.Errorf("expected location: %v, got: %v", loc, got.Location())
+	}
+}
+
+func TestParserFormats(t *testing.T) {
+	p := &relative.Parser{
+		Now:         func() time.Time { return now },
+		DateFormats: []string{"%d.%m.%Y"},
+		TimeFormats: []string{"%Hh%M"},
+	}
+	got, err := p.Parse("24.12.2020 18h30 +1 day")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if expected := time.Date(2020, time.December, 25, 18, 30, 0, 0, time.UTC); !got.Equal(expected) {
+		t.Errorf("expected: %v, got: %v", expected, got)
+	}
+	if _, err := p.Parse("2020-12-24"); err == nil {
+		t.Errorf("expected error but got nil")
+	}
+
+	for _, format := range []string{"%d.%m.%y", "%D", "%m/%d/%C%y", "%s"} {
+		p.DateFormats = []string{format}
+		source := timefmt.Format(time.Date(2019, time.December, 24, 0, 0, 0, 0, time.UTC), format)
+		got, err := p.Parse(source)
+		if err != nil {
+			t.Fatalf("expected no error but got: %v", err)
+		}
+		if expected := time.Date(2019, time.December, 24, 0, 0, 0, 0, time.UTC); !got.Equal(expected) {
+			t.Errorf("%s: expected: %v, got: %v", format, expected, got)
+		}
+	}
+}
+
+func ExampleParse() {
+	now := time.Date(2020, time.July, 24, 15, 4, 5, 0, time.UTC)
+	for _, source := range []string{
+		"tomorrow 9:00", "next friday", "3 days ago",
+		"last day of month", "2020-07-24 +2 weeks",
+	} {
+		t, err := relative.Parse(source, now)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(t)
+	}
+	// Output:
+	// 2020-07-25 09:00:00 +0000 UTC
+	// 2020-07-31 00:00:00 +0000 UTC
+	// 2020-07-21 15:04:05 +0000 UTC
+	// 2020-07-31 15:04:05 +0000 UTC
+	// 2020-08-07 00:00:00 +0000 UTC
+}
@@ -0,0 +1,109 @@
+package timefmt
+
+import (
+	"bufio"
+	"io"
+	"time"
+)
+
+const rewriterBufferSize = 64 * 1024
+
+// Rewriter is an [io.Reader] which rewrites the time strings of the input
+// format in the underlying reader to the output format. By default, only the
+// time strings at the start of lines are rewritten. The other bytes are kept
+// intact.
+//
+// The reader reads line by line, and a line longer than 64 KiB is processed
+// in chunks to bound memory, so a time string crossing a chunk boundary in
+// a long line is not rewritten.
+type Rewriter struct {
+	// Location is used for parsing time strings without time zone (defaults to UTC).
+	Location *time.Location
+	// Zone is the location to convert the times to (defaults to the parsed one).
+	Zone *time.Location
+	// Anywhere enables rewriting the time strings anywhere in lines. The time
+	// strings are looked up at the boundaries of digits and letters.
+	Anywhere bool
+
+	r             *bufio.Reader
+	input, output string
+	finder        *finder
+	buf           []byte
+	offset        int
+	lineStart     bool
+	err           error
+}
+
+// NewRewriter returns a [Rewriter] reading from r, which rewrites the time
+// strings of the input format to the output format.
+func NewRewriter(r io.Reader, input, output string) *Rewriter {
+	return &Rewriter{
+		r:         bufio.NewReaderSize(r, rewriterBufferSize),
+		input:     input,
+		output:    output,
+		lineStart: true,
+	}
+}
+
+// Read implements [io.Reader].
+func (w *Rewriter) Read(p []byte) (int, error) {
+	for w.offset == len(w.buf) {
+		if w.err != nil {
+			return 0, w.err
+		}
+		var line []byte
+		if line, w.err = w.r.ReadSlice('\n'); w.err == bufio.ErrBufferFull {
+			w.err = nil
+		}
+		w.buf, w.offset = w.rewrite(w.buf[:0], line), 0
+		w.lineStart = len(line) > 0 && line[len(line)-1] == '\n'
+	}
+	n := copy(p, w.buf[w.offset:])
+	w.offset += n
+	return n, nil
+}
+
+func (w *Rewriter) rewrite(dst, line []byte) []byte {
+	if len(line) == 0 || !w.Anywhere && !w.lineStart {
+		return append(dst, line...)
+	}
+	if w.finder == nil {
+		loc := w.Location
+		if loc == nil {
+			loc = time.UTC
+		}
+		w.finder = newFinder(w.input, loc)
+	}
+	s := string(line)
+	for i := 0; i < len(s); i++ {
+		if !w.Anywhere && i > 0 {
+			return append(dst, s[i:]...)
+		}
+		if i > 0 || w.lineStart {
+			if t, n, ok := w.finder.match(s, i); ok {
+				if w.Zone != nil {
+					t = t.In(w.Zone)
+				}
+				dst = AppendFormat(dst, t, w.output)
+				i += n - 1
+				continue
+			}
+		}
+		dst = append(dst, s[i])
+	}
+	return dst
+}
+
+// isBoundary reports whether the position between the bytes is not inside
+// a number or a word.
+func isBoundary(prev, next byte) bool {
+	return !(isDigit(prev) && isDigit(next) || isLetter(prev) && isLetter(next))
+}
+
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+func isLetter(b byte) bool {
+	return 'a' <= b|0x20 && b|0x20 <= 'z'
+}
@@ -0,0 +1,155 @@
+package timefmt_test
+
+import (
+	"io"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+)
+
+var rewriterTestCases = []struct {
+	name     string
+	input    string
+	output   string
+	source   string
+	anywhere bool
+	zone     *time.Location
+	expected string
+}{
+	{
+		name:   "access log",
+		input:  "%d/%b/%Y:%T %z",
+		output: "%FT%T%:z",
+		source: "24/Jul/2020:09:07:29 +0900 GET /index.html 200\n" +
+			"24/Jul/2020:09:07:30 +0900 GET /favicon.ico 404\n",
+		expected: "2020-07-24T09:07:29+09:00 GET /index.html 200\n" +
+			"2020-07-24T09:07:30+09:00 GET /favicon.ico 404\n",
+	},
+	{
+		name:     "zone",
+		input:    "%d/%b/%Y:%T %z",
+		output:   "%FT%T%:z",
+		source:   "24/Jul/2020:09:07:29 +0900 GET /\n",
+		zone:     time.UTC,
+		expected: "2020-07-24T00:07:29+00:00 GET /\n",
+	},
+	{
+		name:   "non-matching lines",
+		input:  "%Y-%m-%d %H:%M:%S",
+		output: "%s",
+		source: "2020-07-24 09:07:29 start\n" +
+			"\tat Main.main(Main.java:1)\n" +
+			"error at 2020-07-24 09:07:30\n" +
+			"2020-13-24 09:07:31 invalid\n" +
+			"2020-07-24 09:07:32",
+		expected: "1595581649 start\n" +
+			"\tat Main.main(Main.java:1)\n" +
+			"error at 2020-07-24 09:07:30\n" +
+			"2020-13-24 09:07:31 invalid\n" +
+			"1595581652",
+	},
+	{
+		name:   "anywhere",
+		input:  "%Y-%m-%d %H:%M:%S",
+		output: "%b %e %T",
+		source: "start 2020-07-24 09:07:29, end 2020-07-24 10:00:00.\n" +
+			"12020-07-24 09:07:29 x2020-07-24 09:07:29 (2020-07-04 09:07:29)\n",
+		anywhere: true,
+		expected: "start Jul 24 09:07:29, end Jul 24 10:00:00.\n" +
+			"12020-07-24 09:07:29 xJul 24 09:07:29 (Jul  4 09:07:29)\n",
+	},
+	{
+		name:     "empty",
+		input:    "%Y",
+		output:   "%y",
+		source:   "",
+		expected: "",
+	},
+}
+
+func TestRewriter(t *testing.T) {
+	for _, tc := range rewriterTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, r := range []io.Reader{
+				strings.NewReader(tc.source),
+				iotest.OneByteReader(strings.NewReader(tc.source)),
+			} {
+				w := timefmt.NewRewriter(r, tc.input, tc.output)
+				w.Anywhere, w.Zone = tc.anywhere, tc.zone
+				got, err := io.ReadAll(w)
+				if err != nil {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				if string(got) != tc.expected {
+					t.Errorf("expected: %q, got: %q", tc.expected, string(got))
+				}
+			}
+			w := timefmt.NewRewriter(strings.NewReader(tc.source), tc.input, tc.output)
+			w.Anywhere, w.Zone = tc.anywhere, tc.zone
+			if err := iotest.TestReader(w, []byte(tc.expected)); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestRewriterLocation(t *testing.T) {
+	w := timefmt.NewRewriter(strings.NewReader("2020-07-24 09:07:29 start\n"), "%Y-%m-%d %H:%M:%S", "%FT%T%:z")
+	w.Location = time.FixedZone("JST", 9*60*60)
+	got, err := io.ReadAll(w)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if expected := "2020-07-24T09:07:29+09:00 start\n"; string(got) != expected {
+		t.Errorf("expected: %q, got: %q", expected, string(got))
+	}
+}
+
+func TestRewriterLongLine(t *testing.T) {
+	long := strings.Repeat("x", 100*1024)
+	source := "2020-07-24 09:07:29 " + long + "\n2020-07-24 09:07:30 " + long + " 2020-07-24 09:07:31\n"
+	w := timefmt.NewRewriter(strings.NewReader(source), "%Y-%m-%d %H:%M:%S", "%s")
+	got, err := io.ReadAll(w)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	expected := "1595581649 " + long + "\n1595581650 " + long + " 2020-07-24 09:07:31\n"
+	if string(got) != expected {
+		t.Errorf("expected length %d, got length %d", len(expected), len(got))
+	}
+}
+
+func TestRewriterError(t *testing.T) {
+	w := timefmt.NewRewriter(iotest.DataErrReader(iotest.ErrReader(io.ErrUnexpectedEOF)), "%Y", "%y")
+	if _, err := io.ReadAll(w); err != io.ErrUnexpectedEOF {
+		t.Errorf("expected %v but got: %v", io.ErrUnexpectedEOF, err)
+	}
+}
+
+func ExampleRewriter() {
+	r := strings.NewReader("24/Jul/2020:09:07:29 +0900 GET /index.html 200\n" +
+		"24/Jul/2020:09:07:30 +0900 GET /favicon.ico 404\n")
+	w := timefmt.NewRewriter(r, "%d/%b/%Y:%T %z", "%FT%T%:z")
+	w.Zone = time.UTC
+	if _, err := io.Copy(os.Stdout, w); err != nil {
+		log.Fatal(err)
+	}
+	// Output:
+	// 2020-07-24T00:07:29+00:00 GET /index.html 200
+	// 2020-07-24T00:07:30+00:00 GET /favicon.ico 404
+}
+
+func BenchmarkRewriter(b *testing.B) {
+	source := strings.Repeat("24/Jul/2020:09:07:29 +0900 GET /index.html 200\n", 1000)
+	for b.Loop() {
+		w := timefmt.NewRewriter(strings.NewReader(source), "%d/%b/%Y:%T %z", "%FT%T%:z")
+		if _, err := io.Copy(io.Discard, w); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package timefmt
+
+import (
+	"bufio"
+	"bytes"
+	"time"
+)
+
+// ScanRecords returns a [bufio.SplitFunc] for [bufio.Scanner], which splits
+// the input into the records starting with the time strings of the format.
+// A new record starts at a line beginning with a string parsed by the format,
+// and the other lines are attached to the previous record, like stack traces
+// in the logs. The trailing newline of each record is dropped.
+func ScanRecords(format string) bufio.SplitFunc {
+	f := newFinder(format, time.UTC)
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		if atEOF && len(data) == 0 {
+			return 0, nil, nil
+		}
+		for i := bytes.IndexByte(data, '\n') + 1; i > 0 && i < len(data); {
+			j := bytes.IndexByte(data[i:], '\n')
+			if j < 0 && !atEOF {
+				break
+			}
+			if j < 0 {
+				j = len(data) - i
+			}
+			if _, _, ok := f.match(string(data[i:i+j]), 0); ok {
+				return i, dropNewline(data[:i]), nil
+			}
+			i += j + 1
+		}
+		if atEOF {
+			return len(data), dropNewline(data), nil
+		}
+		return 0, nil, nil
+	}
+}
+
+func dropNewline(data []byte) []byte {
+	if len(data) > 0 && data[len(data)-1] == '\n' {
+		data = data[:len(data)-1]
+		if len(data) > 0 && data[len(data)-1] == '\r' {
+			data = data[:len(data)-1]
+		}
+	}
+	return data
+}
@@ -0,0 +1,123 @@
+package timefmt_test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/itchyny/timefmt-go"
+)
+
+var scanRecordsTestCases = []struct {
+	name     string
+	input    string
+	format   string
+	expected []string
+}{
+	{
+		name:   "stack trace",
+		input:  "2020-07-24 09:07:29 INFO started\n2020-07-24 09:07:30 ERROR failed\njava.lang.RuntimeException: boom\n\tat Main.main(Main.java:3)\n2020-07-24 09:07:31 INFO stopped\n",
+		format: "%Y-%m-%d %H:%M:%S",
+		expected: []string{
+			"2020-07-24 09:07:29 INFO started",
+			"2020-07-24 09:07:30 ERROR failed\njava.lang.RuntimeException: boom\n\tat Main.main(Main.java:3)",
+			"2020-07-24 09:07:31 INFO stopped",
+		},
+	},
+	{
+		name:   "leading lines",
+		input:  "header\n\n[24/Jul/2020:09:07:29 +0900] GET /\n[24/Jul/2020:09:07:30 +0900] GET /favicon.ico",
+		format: "[%d/%b/%Y:%T %z]",
+		expected: []string{
+			"header\n",
+			"[24/Jul/2020:09:07:29 +0900] GET /",
+			"[24/Jul/2020:09:07:30 +0900] GET /favicon.ico",
+		},
+	},
+	{
+		name:   "carriage returns",
+		input:  "Jul 24 09:07:29 host a\r\n  b\r\nJul 24 09:07:30 host c\r\n",
+		format: "%b %e %T",
+		expected: []string{
+			"Jul 24 09:07:29 host a\r\n  b",
+			"Jul 24 09:07:30 host c",
+		},
+	},
+	{
+		name:   "boundary",
+		input:  "2020-07-24 a\n2020-07-241 b\n2020-07-24T09:07:29 c\n",
+		format: "%F",
+		expected: []string{
+			"2020-07-24 a\n2020-07-241 b",
+			"2020-07-24T09:07:29 c",
+		},
+	},
+	{
+		name:     "empty",
+		input:    "",
+		format:   "%F",
+		expected: nil,
+	},
+}
+
+func TestScanRecords(t *testing.T) {
+	for _, tc := range scanRecordsTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, r := range []io.Reader{
+				strings.NewReader(tc.input),
+				iotest.OneByteReader(strings.NewReader(tc.input)),
+			} {
+				scanner := bufio.NewScanner(r)
+				scanner.Split(timefmt.ScanRecords(tc.format))
+				var got []string
+				for scanner.Scan() {
+					got = append(got, scanner.Text())
+				}
+				if err := scanner.Err(); err != nil {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				if !reflect.DeepEqual(got, tc.expected) {
+					t.Errorf("expected: %q, got: %q", tc.expected, got)
+				}
+			}
+		})
+	}
+}
+
+func ExampleScanRecords() {
+	input := `2020-07-24 09:07:29 INFO started
+2020-07-24 09:07:30 ERROR failed
+java.lang.RuntimeException: boom
+	at Main.main(Main.java:3)
+2020-07-24 09:07:31 INFO stopped
+`
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	scanner.Split(timefmt.ScanRecords("%Y-%m-%d %H:%M:%S"))
+	for scanner.Scan() {
+		fmt.Printf("%q\n", scanner.Text())
+	}
+	// Output:
+	// "2020-07-24 09:07:29 INFO started"
+	// "2020-07-24 09:07:30 ERROR failed\njava.lang.RuntimeException: boom\n\tat Main.main(Main.java:3)"
+	// "2020-07-24 09:07:31 INFO stopped"
+}
+
+func BenchmarkScanRecords(b *testing.B) {
+	input := strings.Repeat("2020-07-24 09:07:29 ERROR failed\njava.lang.RuntimeException: boom\n\tat Main.main(Main.java:3)\n", 4096)
+	b.SetBytes(int64(len(input)))
+	for b.Loop() {
+		scanner := bufio.NewScanner(strings.NewReader(input))
+		scanner.Split(timefmt.ScanRecords("%Y-%m-%d %H:%M:%S"))
+		var n int
+		for scanner.Scan() {
+			n++
+		}
+		if n != 4096 {
+			b.Fatalf("expected 4096 records but got: %d", n)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package timefmt
+
+import (
+	"log/slog"
+	"sync"
+)
+
+// SlogReplaceAttr returns a function for [slog.HandlerOptions.ReplaceAttr],
+// which formats the time of the record and the time-valued attributes using
+// the format.
+//
+//	slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+//		ReplaceAttr: timefmt.SlogReplaceAttr("%b %e %T"),
+//	})
+func SlogReplaceAttr(format string) func([]string, slog.Attr) slog.Attr {
+	return func(_ []string, a slog.Attr) slog.Attr {
+		if a.Value.Kind() == slog.KindTime {
+			a.Value = slog.StringValue(Format(a.Value.Time(), format))
+		}
+		return a
+	}
+}
+
+// SlogReplaceAttrBuffered is a variant of [SlogReplaceAttr] which formats the
+// time into a reusable buffer, and reuses the previous string if the formatted
+// string is unchanged. It does not allocate unless the string changes, which
+// occurs once per second at most for a format of second precision.
+func SlogReplaceAttrBuffered(format string) func([]string, slog.Attr) slog.Attr {
+	var mu sync.Mutex
+	var buf []byte
+	var last string
+	return func(_ []string, a slog.Attr) slog.Attr {
+		if a.Value.Kind() == slog.KindTime {
+			mu.Lock()
+			if buf = AppendFormat(buf[:0], a.Value.Time(), format); string(buf) != last {
+				last = string(buf)
+			}
+			a.Value = slog.StringValue(last)
+			mu.Unlock()
+		}
+		return a
+	}
+}
+
+// SlogHandlerOptions returns a copy of the handler options with ReplaceAttr
+// formatting the times using [SlogReplaceAttrBuffered]. The original
+// ReplaceAttr, if any, is applied before formatting the times.
+func SlogHandlerOptions(opts *slog.HandlerOptions, format string) *slog.HandlerOptions {
+	var o slog.HandlerOptions
+	if opts != nil {
+		o = *opts
+	}
+	replace, replaceTime := o.ReplaceAttr, SlogReplaceAttrBuffered(format)
+	if replace == nil {
+		o.ReplaceAttr = replaceTime
+	} else {
+		o.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+			return replaceTime(groups, replace(groups, a))
+		}
+	}
+	return &o
+}
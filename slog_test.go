@@ -0,0 +1,103 @@
+package timefmt_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+)
+
+func TestSlogReplaceAttr(t *testing.T) {
+	for _, replaceAttr := range []func([]string, slog.Attr) slog.Attr{
+		timefmt.SlogReplaceAttr("%b %e %T"),
+		timefmt.SlogReplaceAttrBuffered("%b %e %T"),
+	} {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{ReplaceAttr: replaceAttr}))
+		r := slog.NewRecord(time.Date(2020, time.July, 4, 9, 7, 29, 0, time.UTC), slog.LevelInfo, "hello", 0)
+		r.AddAttrs(
+			slog.Time("deadline", time.Date(2020, time.July, 24, 18, 0, 0, 0, time.UTC)),
+			slog.Group("job", slog.Any("start", time.Date(2020, time.July, 1, 0, 0, 0, 0, time.UTC))),
+			slog.Int("count", 1),
+		)
+		if err := logger.Handler().Handle(context.Background(), r); err != nil {
+			t.Fatal(err)
+		}
+		expected := `time="Jul  4 09:07:29" level=INFO msg=hello deadline="Jul 24 18:00:00" ` +
+			`job.start="Jul  1 00:00:00" count=1` + "\n"
+		if got := buf.String(); got != expected {
+			t.Errorf("expected: %q, got: %q", expected, got)
+		}
+	}
+}
+
+func TestSlogReplaceAttrBufferedAllocs(t *testing.T) {
+	replaceAttr := timefmt.SlogReplaceAttrBuffered("%b %e %T")
+	a := slog.Time(slog.TimeKey, time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC))
+	replaceAttr(nil, a)
+	if allocs := testing.AllocsPerRun(100, func() { replaceAttr(nil, a) }); allocs != 0 {
+		t.Errorf("expected no allocations but got: %v", allocs)
+	}
+}
+
+func TestSlogHandlerOptions(t *testing.T) {
+	var buf bytes.Buffer
+	opts := timefmt.SlogHandlerOptions(&slog.HandlerOptions{
+		Level: slog.LevelWarn,
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.LevelKey {
+				a.Key = "severity"
+			}
+			return a
+		},
+	}, "%Y/%m/%d %H:%M:%S")
+	logger := slog.New(slog.NewJSONHandler(&buf, opts))
+	r := slog.NewRecord(time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC), slog.LevelWarn, "hello", 0)
+	if err := logger.Handler().Handle(context.Background(), r); err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"time":"2020/07/24 09:07:29","severity":"WARN","msg":"hello"}` + "\n"
+	if got := buf.String(); got != expected {
+		t.Errorf("expected: %q, got: %q", expected, got)
+	}
+	if logger.Enabled(context.Background(), slog.LevelInfo) {
+		t.Errorf("expected the level option to be kept")
+	}
+	if opts := timefmt.SlogHandlerOptions(nil, "%T"); opts.ReplaceAttr == nil {
+		t.Errorf("expected ReplaceAttr to be set")
+	}
+}
+
+func ExampleSlogReplaceAttr() {
+	handler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		ReplaceAttr: timefmt.SlogReplaceAttr("%b %e %T"),
+	})
+	r := slog.NewRecord(time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC), slog.LevelInfo, "hello", 0)
+	r.AddAttrs(slog.Time("deadline", time.Date(2020, time.July, 24, 18, 0, 0, 0, time.UTC)))
+	if err := handler.Handle(context.Background(), r); err != nil {
+		log.Fatal(err)
+	}
+	// Output:
+	// time="Jul 24 09:07:29" level=INFO msg=hello deadline="Jul 24 18:00:00"
+}
+
+func BenchmarkSlogReplaceAttr(b *testing.B) {
+	replaceAttr := timefmt.SlogReplaceAttr("%b %e %T")
+	a := slog.Time(slog.TimeKey, time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC))
+	for b.Loop() {
+		replaceAttr(nil, a)
+	}
+}
+
+func BenchmarkSlogReplaceAttrBuffered(b *testing.B) {
+	replaceAttr := timefmt.SlogReplaceAttrBuffered("%b %e %T")
+	a := slog.Time(slog.TimeKey, time.Date(2020, time.July, 24, 9, 7, 29, 0, time.UTC))
+	for b.Loop() {
+		replaceAttr(nil, a)
+	}
+}
@@ -0,0 +1,332 @@
+package timefmt
+
+import "fmt"
+
+// Token is a literal string or a directive in a format.
+type Token struct {
+	Literal   string // the literal string, or the raw text of the directive
+	Directive byte   // the directive character, or 0 for a literal string
+	Modifier  byte   // the modifier character, like 'E' of the fiscal directive %EY
+	Padding   byte   // the padding flag; '-', '_' or '0', or 0 for the default
+	Width     int    // the width, or 0 for the default
+	Upper     bool   // the ^ flag to convert to upper case
+	Swap      bool   // the # flag to swap the case
+	Colons    int    // the number of colons of %z
+	Composite byte   // the composite directive expanded to the token, like 'F' of %F
+}
+
+// ParseFormat splits the format into the literal strings and the directives.
+// The composite directives like %F are expanded, and %% is converted to the
+// literal string unless it has the width like %7%. The fiscal directives like
+// %EY are accepted with Modifier 'E'. It returns an error on the unknown
+// directives.
+func ParseFormat(format string) ([]Token, error) {
+	tokens := scanFormat(format)
+	for _, token := range tokens {
+		if token.Directive != 0 && !token.isKnown() {
+			return nil, fmt.Errorf("unknown directive %q", token.Literal)
+		}
+	}
+	return tokens, nil
+}
+
+// compositeFormats is the table of the directives composed of the others.
+var compositeFormats = map[byte]string{
+	'c': "%a %b %e %H:%M:%S %Y",
+	'+': "%a %b %e %H:%M:%S %Z %Y",
+	'v': "%e-%b-%Y",
+	'r': "%I:%M:%S %p",
+	'F': "%Y-%m-%d",
+	'D': "%m/%d/%y",
+	'x': "%m/%d/%y",
+	'T': "%H:%M:%S",
+	'X': "%H:%M:%S",
+	'R': "%H:%M",
+}
+
+// compositeDirectives is the table of compositeFormats indexed by the
+// directive for the tokenizer.
+var compositeDirectives = func() (table [256]string) {
+	for b, format := range compositeFormats {
+		table[b] = format
+	}
+	return
+}()
+
+// scanFormat splits the format into the tokens. The composite directives are
+// expanded, and the adjacent literal strings are concatenated. The unknown
+// directives are kept as the tokens, with the raw text in the Literal field.
+func scanFormat(format string) []Token {
+	var tokens []Token
+	t := tokenizer{format: format}
+	for t.next() {
+		token := t.token
+		switch token.Directive {
+		case '%':
+			if token.Width == 0 {
+				token = Token{Literal: "%"}
+			}
+		case 0, ':':
+			token = Token{Literal: token.Literal}
+		}
+		if n := len(tokens); n > 0 && token.Directive == 0 && tokens[n-1].Directive == 0 {
+			tokens[n-1].Literal += token.Literal
+		} else {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// tokenizer scans the tokens of the format one by one, from which the
+// formatter and the parser derive. The composite directives are expanded, but
+// unlike scanFormat, the literal strings are not concatenated, %% is kept as
+// the directive, and the colons not followed by z are scanned as the directive
+// ':' with the number of the colons. An incomplete directive at the end of the
+// format is scanned as the literal string with the flags and the width.
+type tokenizer struct {
+	format    string
+	pending   string // the rest of the expanded composite directive
+	composite byte   // the composite directive being expanded
+	upper     bool   // the ^ flag of the composite directive
+	token     Token  // the current token
+}
+
+// next scans the next token, and reports false at the end of the format.
+func (t *tokenizer) next() bool {
+	format, expanding := t.pending, t.pending != ""
+	if !expanding {
+		if format = t.format; format == "" {
+			return false
+		}
+	}
+	token, i := &t.token, 1
+	if format[0] != '%' {
+		for i < len(format) && format[i] != '%' {
+			i++
+		}
+		*token = Token{Literal: format[:i]}
+	} else {
+		*token = Token{}
+	L:
+		for ; i < len(format); i++ {
+			switch b := format[i]; b {
+			case '-', '_', '0':
+				if i+1 == len(format) {
+					break // the flag at the end is not applied to the incomplete directive
+				}
+				if token.Padding = b; b == '-' {
+					token.Width = 0 // the formatter ignores the preceding width
+				}
+			case '^':
+				token.Upper = true
+			case '#':
+				token.Swap = true
+			case '1', '2', '3', '4', '5', '6', '7', '8', '9':
+				for ; i < len(format) && '0' <= format[i] && format[i] <= '9'; i++ {
+					token.Width = min(token.Width*10+int(format[i]&0x0F), 1024)
+				}
+				i--
+			case ':':
+				for token.Colons = 1; i+token.Colons < len(format) &&
+					format[i+token.Colons] == ':'; token.Colons++ {
+				}
+				i += token.Colons
+				if token.Colons <= 3 && i < len(format) && format[i] == 'z' {
+					token.Directive = 'z'
+				} else {
+					token.Directive, i = b, i-1
+				}
+				break L
+			case 'E':
+				if i+1 < len(format) && isFiscalDirective(format[i+1]) {
+					token.Modifier, i = b, i+1
+				}
+				token.Directive = format[i]
+				break L
+			default:
+				token.Directive = b
+				break L
+			}
+		}
+		i = min(i+1, len(format))
+		token.Literal = format[:i]
+	}
+	if expanding {
+		if t.pending = format[i:]; token.Directive != 0 {
+			token.Upper, token.Composite = t.upper, t.composite
+		}
+		return true
+	}
+	t.format = format[i:]
+	if t.pending = compositeDirectives[token.Directive]; t.pending != "" {
+		t.composite, t.upper = token.Directive, token.Upper
+		return t.next()
+	}
+	return true
+}
+
+// isKnown reports whether the directive is supported.
+func (t Token) isKnown() bool {
+	_, ok := lookupDirective(t.Modifier, t.Directive)
+	return ok
+}
+
+// paddingByte returns the padding byte for appendInt and appendString. The
+// width with the - flag pads with spaces as the formatter does.
+func (t Token) paddingByte() byte {
+	switch t.Padding {
+	case '-':
+		if t.Width > 0 {
+			return ' ' | ^paddingMask
+		}
+		return ^paddingMask
+	case '_':
+		return ' ' | ^paddingMask
+	case '0':
+		return '0' | ^paddingMask
+	default:
+		return '0'
+	}
+}
+
+// numberWidth returns the width of the numeric directive padded by the
+// formatter, which defaults to the digits of the maximum value.
+func (t Token) numberWidth(info DirectiveInfo) int {
+	switch t.Directive {
+	case 'Y', 'G', 'f':
+		return or(t.Width, info.digits()) // the width can be narrower than the default
+	default:
+		return max(t.Width, info.digits())
+	}
+}
+
+// isDigits reports whether the directive is formatted as the digits, which
+// stops the parser of the preceding %f at microseconds.
+func (t Token) isDigits() bool {
+	info, ok := lookupDirective(t.Modifier, t.Directive)
+	return ok && info.Numeric && t.Directive != 'z'
+}
+
+// spacePadded reports whether the directive is padded with spaces by default.
+func spacePadded(directive byte) bool {
+	return directive == 'e' || directive == 'k' || directive == 'l' || directive == 's'
+}
+
+// DirectiveInfo describes a directive of the format.
+type DirectiveInfo struct {
+	Modifier    byte // the modifier character, like 'E' of the fiscal directive %EY
+	Directive   byte
+	Description string
+	Min, Max    int    // the range of the value, or zeros if not applicable
+	Composite   string // the expanded format of the composite directive
+	Placeholder string // the placeholder of the value, like YYYY for %Y
+	Numeric     bool   // formatted as the digits
+	Format      bool   // supported by Format, or only by FiscalCalendar if false
+	Parse       bool   // supported by Parse, or only by FiscalCalendar if false
+}
+
+var directiveInfos = []DirectiveInfo{
+	{Directive: 'Y', Description: "year", Min: -9999, Max: 9999, Placeholder: "YYYY", Numeric: true, Format: true, Parse: true},
+	{Directive: 'y', Description: "year without century", Max: 99, Placeholder: "YY", Numeric: true, Format: true, Parse: true},
+	{Directive: 'C', Description: "century", Min: -99, Max: 99, Placeholder: "CC", Numeric: true, Format: true, Parse: true},
+	{Directive: 'g', Description: "ISO 8601 week-based year without century", Max: 99, Placeholder: "GG", Numeric: true, Format: true, Parse: true},
+	{Directive: 'G', Description: "ISO 8601 week-based year", Min: -9999, Max: 9999, Placeholder: "GGGG", Numeric: true, Format: true, Parse: true},
+	{Directive: 'm', Description: "month", Min: 1, Max: 12, Placeholder: "MM", Numeric: true, Format: true, Parse: true},
+	{Directive: 'q', Description: "quarter of year", Min: 1, Max: 4, Placeholder: "Q", Numeric: true, Format: true, Parse: true},
+	{Directive: 'Q', Description: "half of year", Min: 1, Max: 2, Placeholder: "H", Numeric: true, Format: true, Parse: true},
+	{Directive: 'B', Description: "full month name", Min: 1, Max: 12, Placeholder: "Month", Format: true, Parse: true},
+	{Directive: 'b', Description: "abbreviated month name", Min: 1, Max: 12, Placeholder: "Mon", Format: true, Parse: true},
+	{Directive: 'h', Description: "abbreviated month name (same as %b)", Min: 1, Max: 12, Placeholder: "Mon", Format: true, Parse: true},
+	{Directive: 'A', Description: "full weekday name", Max: 6, Placeholder: "Weekday", Format: true, Parse: true},
+	{Directive: 'a', Description: "abbreviated weekday name", Max: 6, Placeholder: "Wkd", Format: true, Parse: true},
+	{Directive: 'w', Description: "weekday from Sunday as 0", Max: 6, Placeholder: "w", Numeric: true, Format: true, Parse: true},
+	{Directive: 'u', Description: "weekday from Monday as 1", Min: 1, Max: 7, Placeholder: "u", Numeric: true, Format: true, Parse: true},
+	{Directive: 'V', Description: "ISO 8601 week number; parsed with %G", Min: 1, Max: 53, Placeholder: "WW", Numeric: true, Format: true, Parse: true},
+	{Directive: 'U', Description: "week number of year from Sunday", Max: 53, Placeholder: "WW", Numeric: true, Format: true, Parse: true},
+	{Directive: 'W', Description: "week number of year from Monday", Max: 53, Placeholder: "WW", Numeric: true, Format: true, Parse: true},
+	{Directive: 'e', Description: "day of month padded with space", Min: 1, Max: 31, Placeholder: "DD", Numeric: true, Format: true, Parse: true},
+	{Directive: 'd', Description: "day of month", Min: 1, Max: 31, Placeholder: "DD", Numeric: true, Format: true, Parse: true},
+	{Directive: 'o', Description: "day of month with ordinal suffix", Min: 1, Max: 31, Placeholder: "DDth", Format: true, Parse: true},
+	{Directive: 'j', Description: "day of year", Min: 1, Max: 366, Placeholder: "DDD", Numeric: true, Format: true, Parse: true},
+	{Directive: 'k', Description: "hour padded with space", Max: 23, Placeholder: "hh", Numeric: true, Format: true, Parse: true},
+	{Directive: 'H', Description: "hour", Max: 23, Placeholder: "hh", Numeric: true, Format: true, Parse: true},
+	{Directive: 'l', Description: "hour of 12-hour clock padded with space", Min: 1, Max: 12, Placeholder: "hh", Numeric: true, Format: true, Parse: true},
+	{Directive: 'I', Description: "hour of 12-hour clock", Min: 1, Max: 12, Placeholder: "hh", Numeric: true, Format: true, Parse: true},
+	{Directive: 'P', Description: "am or pm", Max: 1, Placeholder: "am", Format: true, Parse: true},
+	{Directive: 'p', Description: "AM or PM", Max: 1, Placeholder: "AM", Format: true, Parse: true},
+	{Directive: 'M', Description: "minute", Max: 59, Placeholder: "mm", Numeric: true, Format: true, Parse: true},
+	{Directive: 'S', Description: "second", Max: 60, Placeholder: "ss", Numeric: true, Format: true, Parse: true},
+	{Directive: 's', Description: "seconds since the Unix epoch", Placeholder: "unix", Numeric: true, Format: true, Parse: true},
+	{Directive: 'f', Description: "microsecond; parsed up to nanoseconds unless followed by a numeric directive", Max: 999999, Placeholder: "ffffff", Numeric: true, Format: true, Parse: true},
+	{Directive: 'Z', Description: "time zone name", Placeholder: "TZ", Format: true, Parse: true},
+	{Directive: 'z', Description: "time zone offset; +hhmm, +hh:mm with %:z, +hh:mm:ss with %::z, and the shortest with %:::z", Placeholder: "+hhmm", Numeric: true, Format: true, Parse: true},
+	{Directive: 't', Description: "tab character; whitespaces on parsing", Placeholder: "\t", Format: true, Parse: true},
+	{Directive: 'n', Description: "newline character; whitespaces on parsing", Placeholder: "\n", Format: true, Parse: true},
+	{Directive: 'c', Description: "date and time representation", Format: true, Parse: true},
+	{Directive: '+', Description: "date and time representation with time zone name", Format: true, Parse: true},
+	{Directive: 'v', Description: "day, month name and year", Format: true, Parse: true},
+	{Directive: 'r', Description: "time of 12-hour clock", Format: true, Parse: true},
+	{Directive: 'F', Description: "ISO 8601 date", Format: true, Parse: true},
+	{Directive: 'D', Description: "date with slashes", Format: true, Parse: true},
+	{Directive: 'x', Description: "date representation (same as %D)", Format: true, Parse: true},
+	{Directive: 'T', Description: "ISO 8601 time", Format: true, Parse: true},
+	{Directive: 'X', Description: "time representation (same as %T)", Format: true, Parse: true},
+	{Directive: 'R', Description: "hour and minute", Format: true, Parse: true},
+	{Directive: '%', Description: "percent sign", Placeholder: "%", Format: true, Parse: true},
+	{Modifier: 'E', Directive: 'Y', Description: "fiscal year", Min: -9999, Max: 9999, Placeholder: "YYYY", Numeric: true},
+	{Modifier: 'E', Directive: 'y', Description: "fiscal year without century", Max: 99, Placeholder: "YY", Numeric: true},
+	{Modifier: 'E', Directive: 'q', Description: "fiscal quarter; parsed with %EY", Min: 1, Max: 4, Placeholder: "Q", Numeric: true},
+	{Modifier: 'E', Directive: 'm', Description: "fiscal period; parsed with %EY", Min: 1, Max: 12, Placeholder: "PP", Numeric: true},
+	{Modifier: 'E', Directive: 'V', Description: "fiscal week; parsed with %EY", Min: 1, Max: 53, Placeholder: "WW", Numeric: true},
+}
+
+// Directives returns the information of the directives supported by the
+// format and the parser, including the composite directives and the fiscal
+// directives supported by [FiscalCalendar].
+func Directives() []DirectiveInfo {
+	infos := make([]DirectiveInfo, len(directiveInfos))
+	for i, info := range directiveInfos {
+		if info.Modifier == 0 {
+			info.Composite = compositeFormats[info.Directive]
+		}
+		infos[i] = info
+	}
+	return infos
+}
+
+// directiveTable is the index of directiveInfos by the directive, without
+// the modifier and with the modifier 'E'.
+var directiveTable = func() (table [2][256]*DirectiveInfo) {
+	for i := range directiveInfos {
+		info := &directiveInfos[i]
+		table[min(int(info.Modifier), 1)][info.Directive] = info
+	}
+	return
+}()
+
+// lookupDirective returns the information of the directive with the modifier,
+// which is zero for the directives without modifier.
+func lookupDirective(modifier, directive byte) (DirectiveInfo, bool) {
+	var info *DirectiveInfo
+	switch modifier {
+	case 0:
+		info = directiveTable[0][directive]
+	case 'E':
+		info = directiveTable[1][directive]
+	}
+	if info == nil {
+		return DirectiveInfo{}, false
+	}
+	return *info, true
+}
+
+// digits returns the number of the digits of the maximum value, which is the
+// default width of the numeric directive.
+func (info DirectiveInfo) digits() int {
+	n := 1
+	for x := info.Max; x >= 10; x /= 10 {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,199 @@
+package timefmt_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+)
+
+var parseFormatTestCases = []struct {
+	format   string
+	expected []timefmt.Token
+}{
+	{
+		format: "%Y-%m-%d",
+		expected: []timefmt.Token{
+			{Literal: "%Y", Directive: 'Y'},
+			{Literal: "-"},
+			{Literal: "%m", Directive: 'm'},
+			{Literal: "-"},
+			{Literal: "%d", Directive: 'd'},
+		},
+	},
+	{
+		format: "%-d %_3e %010Y %^#b %-5a",
+		expected: []timefmt.Token{
+			{Literal: "%-d", Directive: 'd', Padding: '-'},
+			{Literal: " "},
+			{Literal: "%_3e", Directive: 'e', Padding: '_', Width: 3},
+			{Literal: " "},
+			{Literal: "%010Y", Directive: 'Y', Padding: '0', Width: 10},
+			{Literal: " "},
+			{Literal: "%^#b", Directive: 'b', Upper: true, Swap: true},
+			{Literal: " "},
+			{Literal: "%-5a", Directive: 'a', Padding: '-', Width: 5},
+		},
+	},
+	{
+		format: "FY%EY-%Eq %_3EV",
+		expected: []timefmt.Token{
+			{Literal: "FY"},
+			{Literal: "%EY", Directive: 'Y', Modifier: 'E'},
+			{Literal: "-"},
+			{Literal: "%Eq", Directive: 'q', Modifier: 'E'},
+			{Literal: " "},
+			{Literal: "%_3EV", Directive: 'V', Modifier: 'E', Padding: '_', Width: 3},
+		},
+	},
+	{
+		format: "%z %:z %::z %:::z %::::z",
+		expected: []timefmt.Token{
+			{Literal: "%z", Directive: 'z'},
+			{Literal: " "},
+			{Literal: "%:z", Directive: 'z', Colons: 1},
+			{Literal: " "},
+			{Literal: "%::z", Directive: 'z', Colons: 2},
+			{Literal: " "},
+			{Literal: "%:::z", Directive: 'z', Colons: 3},
+			{Literal: " %::::z"},
+		},
+	},
+	{
+		format: "[%^F %T] 100%%",
+		expected: []timefmt.Token{
+			{Literal: "["},
+			{Literal: "%Y", Directive: 'Y', Upper: true, Composite: 'F'},
+			{Literal: "-"},
+			{Literal: "%m", Directive: 'm', Upper: true, Composite: 'F'},
+			{Literal: "-"},
+			{Literal: "%d", Directive: 'd', Upper: true, Composite: 'F'},
+			{Literal: " "},
+			{Literal: "%H", Directive: 'H', Composite: 'T'},
+			{Literal: ":"},
+			{Literal: "%M", Directive: 'M', Composite: 'T'},
+			{Literal: ":"},
+			{Literal: "%S", Directive: 'S', Composite: 'T'},
+			{Literal: "] 100%"},
+		},
+	},
+	{
+		format: "S n%7%%-%",
+		expected: []timefmt.Token{
+			{Literal: "S n"},
+			{Literal: "%7%", Directive: '%', Width: 7},
+			{Literal: "%"},
+		},
+	},
+	{
+		format: "%",
+		expected: []timefmt.Token{
+			{Literal: "%"},
+		},
+	},
+	{
+		format:   "",
+		expected: nil,
+	},
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, tc := range parseFormatTestCases {
+		t.Run(tc.format, func(t *testing.T) {
+			got, err := timefmt.ParseFormat(tc.format)
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected: %+v, got: %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseFormatError(t *testing.T) {
+	for _, tc := range []struct {
+		format, expected string
+	}{
+		{"%Y %E", `unknown directive "%E"`},
+		{"%EY %Ed", `unknown directive "%E"`},
+	} {
+		if _, err := timefmt.ParseFormat(tc.format); err == nil || err.Error() != tc.expected {
+			t.Errorf("%s: expected error %q but got: %v", tc.format, tc.expected, err)
+		}
+	}
+}
+
+func TestDirectives(t *testing.T) {
+	tm := time.Date(2020, time.July, 24, 9, 7, 29, 123456000, time.UTC)
+	for _, info := range timefmt.Directives() {
+		format := "%" + string(info.Directive)
+		if info.Modifier != 0 {
+			format = "%" + string(info.Modifier) + string(info.Directive)
+		}
+		t.Run(format, func(t *testing.T) {
+			if info.Description == "" || info.Min > info.Max {
+				t.Errorf("invalid directive information: %+v", info)
+			}
+			tokens, err := timefmt.ParseFormat(format)
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+			if info.Composite != "" {
+				expected, err := timefmt.ParseFormat(info.Composite)
+				if err != nil {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				for i, token := range tokens {
+					if token.Directive != 0 && token.Composite != info.Directive {
+						t.Errorf("expected composite %q but got: %+v", info.Directive, token)
+					}
+					tokens[i].Composite = 0
+				}
+				if !reflect.DeepEqual(tokens, expected) {
+					t.Errorf("expected: %+v, got: %+v", expected, tokens)
+				}
+			} else if info.Directive != '%' && (len(tokens) != 1 || tokens[0].Directive != info.Directive ||
+				tokens[0].Modifier != info.Modifier) {
+				t.Errorf("expected the directive %q but got: %+v", info.Directive, tokens)
+			}
+			s := timefmt.Format(tm, format)
+			if info.Format != (s != format) {
+				t.Errorf("expected %q to be formatted: %v, got: %q", format, info.Format, s)
+			}
+			if info.Modifier != 0 {
+				cal := &timefmt.FiscalCalendar{StartMonth: time.April}
+				if s := cal.Format(tm, format); s == format {
+					t.Errorf("expected %q to be formatted by FiscalCalendar", format)
+				}
+				if _, err := cal.Parse(cal.Format(tm, "%EY "+format), "%EY "+format); err != nil {
+					t.Errorf("expected no error but got: %v", err)
+				}
+			}
+			if info.Directive == 'V' {
+				format = "%G " + format
+				s = timefmt.Format(tm, format)
+			}
+			if _, err := timefmt.Parse(s, format); info.Parse != (err == nil) {
+				t.Errorf("expected %q to be parsed: %v, got error: %v", format, info.Parse, err)
+			}
+		})
+	}
+}
+
+func TestDirectivesKnown(t *testing.T) {
+	known := make(map[byte]bool)
+	for _, info := range timefmt.Directives() {
+		known[info.Directive] = true
+	}
+	for b := byte('!'); b <= '~'; b++ {
+		if strings.IndexByte("-_0123456789^#:", b) >= 0 {
+			continue
+		}
+		if _, err := timefmt.ParseFormat("%" + string(b)); known[b] != (err == nil) {
+			t.Errorf("expected the directive %q to be known: %v, got error: %v", b, known[b], err)
+		}
+	}
+}